@@ -36,23 +36,11 @@ locals {
   // setproduct([a,b,c], [x,y,z]) => returns a list with all possible combinations of a,b,c and x,y,z
   coordinates = setproduct(local.x_values, local.y_values, local.z_values)
 
-  // zipmap(["a","b","c"], [1,2,3]) => returns an object with keys of the first array and values of the second array 
-  // e.g. {"a": 1, "b": 2, "c": 3} 
-  blocks   = [for coordinate in local.coordinates : zipmap(["x", "y", "z"], coordinate)]
   material = length(regexall("^[a-z]+:[a-z]+$", var.material)) > 0 ? var.material : format("%s:%s", "minecraft", var.material)
 }
 
-resource "minecraft_block" "cube" {
-  // loop over the blocks and set the index as the id
-  for_each = { for i, o in local.blocks : "block-${i}" => o }
-
-  // use the computed material as the material
-  material = local.material
-
-  // use the position of each block as its position
-  position = {
-    x = each.value.x,
-    y = each.value.y,
-    z = each.value.z
-  }
+resource "minecraft_blocks" "cube" {
+  // one map entry per block, keyed by its "x,y,z" position
+  // adjacent blocks of the same material are merged into a few /fill commands
+  blocks = { for coordinate in local.coordinates : join(",", coordinate) => local.material }
 }
@@ -12,7 +12,7 @@ resource "minecraft_bed" "b1" {
 
 # Another example: bed facing north (head at z-1)
 resource "minecraft_bed" "guest" {
-  material = "minecraft:blue_bed"
+  color = "blue"
   position = {
     x = -198
     y = 66
@@ -0,0 +1,30 @@
+# The lobby is always a cherry grove
+resource "minecraft_biome" "lobby" {
+  start = {
+    x = -32
+    y = 50
+    z = -32
+  }
+  end = {
+    x = 31
+    y = 120
+    z = 31
+  }
+  biome = "minecraft:cherry_grove"
+}
+
+# Turn the forests around the arena to snow, leaving other biomes alone
+resource "minecraft_biome" "arena_snow" {
+  start = {
+    x = 200
+    y = 40
+    z = 200
+  }
+  end = {
+    x = 300
+    y = 140
+    z = 300
+  }
+  biome   = "minecraft:snowy_taiga"
+  replace = "#minecraft:is_forest"
+}
@@ -7,3 +7,38 @@ resource "minecraft_block" "stone" {
     z = -195
   }
 }
+
+resource "minecraft_block" "portal_frame" {
+  material  = "minecraft:obsidian"
+  dimension = "minecraft:the_nether"
+
+  position = {
+    x = 8
+    y = 70
+    z = -4
+  }
+}
+
+resource "minecraft_block" "beam" {
+  material = "minecraft:oak_log"
+  state = {
+    axis = "x"
+  }
+
+  position = {
+    x = -197
+    y = 66
+    z = -195
+  }
+}
+
+resource "minecraft_block" "zombie_spawner" {
+  material = "minecraft:spawner"
+  nbt      = "{SpawnData:{entity:{id:\"minecraft:zombie\"}},Delay:20s}"
+
+  position = {
+    x = -196
+    y = 66
+    z = -195
+  }
+}
@@ -0,0 +1,13 @@
+# A small stone platform with an oak log border, written with a handful of /fill commands
+locals {
+  xs = range(-200, -190)
+  zs = range(-200, -190)
+}
+
+resource "minecraft_blocks" "platform" {
+  blocks = merge(
+    { for c in setproduct(local.xs, local.zs) : "${c[0]},64,${c[1]}" => "minecraft:stone" },
+    { for x in local.xs : "${x},65,-200" => "minecraft:oak_log[axis=x]" },
+    { for x in local.xs : "${x},65,-191" => "minecraft:oak_log[axis=x]" },
+  )
+}
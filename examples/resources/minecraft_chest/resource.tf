@@ -10,3 +10,42 @@ resource "minecraft_chest" "example" {
     z = 10
   }
 }
+
+resource "minecraft_chest" "armory" {
+  size        = "single"
+  facing      = "south"
+  custom_name = "Armory"
+  lock        = "Armory Key"
+
+  position = {
+    x = 12
+    y = 64
+    z = 10
+  }
+
+  items = [
+    {
+      slot         = 0
+      item         = "minecraft:diamond_sword"
+      custom_name  = "Excalibur"
+      lore         = ["Pulled from the stone"]
+      enchantments = { "minecraft:sharpness" = 5 }
+    },
+    {
+      slot  = 1
+      item  = "minecraft:golden_apple"
+      count = 16
+    },
+  ]
+}
+
+resource "minecraft_chest" "dungeon" {
+  size       = "single"
+  loot_table = "minecraft:chests/simple_dungeon"
+
+  position = {
+    x = 14
+    y = 64
+    z = 10
+  }
+}
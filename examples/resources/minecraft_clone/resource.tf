@@ -0,0 +1,41 @@
+# A second copy of the arena, next to the first
+resource "minecraft_clone" "arena_copy" {
+  source_start = {
+    x = -300
+    y = 60
+    z = -300
+  }
+  source_end = {
+    x = -200
+    y = 90
+    z = -200
+  }
+  destination = {
+    x = -150
+    y = 60
+    z = -300
+  }
+}
+
+# Only the stone bricks of the nether fort, copied to the overworld (1.20.2+)
+resource "minecraft_clone" "fort_walls" {
+  source_dimension = "minecraft:the_nether"
+  source_start = {
+    x = 100
+    y = 70
+    z = 100
+  }
+  source_end = {
+    x = 120
+    y = 80
+    z = 120
+  }
+  destination = {
+    x = 0
+    y = 100
+    z = 0
+  }
+
+  mask_mode = "filtered"
+  filter    = "minecraft:nether_bricks"
+}
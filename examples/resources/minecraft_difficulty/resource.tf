@@ -0,0 +1,3 @@
+resource "minecraft_difficulty" "default" {
+  difficulty = "hard"
+}
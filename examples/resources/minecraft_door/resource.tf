@@ -0,0 +1,25 @@
+# An oak door facing south: lower half at (-198,66,-195), upper half above it
+resource "minecraft_door" "front" {
+  material = "minecraft:oak_door"
+  position = {
+    x = -198
+    y = 66
+    z = -195
+  }
+
+  facing = "south"
+  hinge  = "right"
+}
+
+# An iron door left open
+resource "minecraft_door" "vault" {
+  material = "minecraft:iron_door"
+  position = {
+    x = -190
+    y = 66
+    z = -195
+  }
+
+  facing = "east"
+  open   = true
+}
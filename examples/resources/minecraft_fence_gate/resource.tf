@@ -0,0 +1,25 @@
+# An oak fence gate between two fences; in_wall is worked out from the neighbours
+resource "minecraft_fence_gate" "pasture" {
+  material = "minecraft:oak_fence_gate"
+  position = {
+    x = -198
+    y = 66
+    z = -195
+  }
+
+  facing = "north"
+}
+
+# A gate set into a cobblestone wall, left open
+resource "minecraft_fence_gate" "garden" {
+  material = "minecraft:birch_fence_gate"
+  position = {
+    x = -190
+    y = 66
+    z = -195
+  }
+
+  facing  = "east"
+  open    = true
+  in_wall = true
+}
@@ -0,0 +1,37 @@
+# A 64 block wide banner on a wall facing south, in wool
+resource "minecraft_image" "logo" {
+  path = "${path.module}/logo.png"
+  position = {
+    x = -200
+    y = 120
+    z = -220
+  }
+
+  orientation = "south"
+  width       = 64
+  dither      = true
+}
+
+# A floor mosaic in a hand-picked palette
+resource "minecraft_image" "mosaic" {
+  path = "${path.module}/mosaic.jpg"
+  position = {
+    x = -200
+    y = 65
+    z = -210
+  }
+
+  orientation = "floor"
+  width       = 32
+  height      = 32
+  custom_palette = {
+    "minecraft:white_concrete" = "#cfd5d6"
+    "minecraft:black_concrete" = "#080a0f"
+    "minecraft:gold_block"     = "#f6d03d"
+    "minecraft:lapis_block"    = "#1f4591"
+    "minecraft:redstone_block" = "#af1905"
+    "minecraft:emerald_block"  = "#2ad36b"
+    "minecraft:prismarine"     = "#63a196"
+    "minecraft:oak_planks"     = "#a2834f"
+  }
+}
@@ -0,0 +1,10 @@
+# Respawn the event host at the nether arena
+resource "minecraft_player_spawnpoint" "host" {
+  player = "Steve"
+  position = {
+    x = 100
+    y = 70
+    z = -40
+  }
+  dimension = "minecraft:the_nether"
+}
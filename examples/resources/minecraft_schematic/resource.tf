@@ -0,0 +1,27 @@
+# A house saved with WorldEdit, placed facing the other way
+resource "minecraft_schematic" "house" {
+  path = "${path.module}/house.schem"
+  position = {
+    x = 120
+    y = 64
+    z = -40
+  }
+
+  rotation = 180
+
+  # Keep the trees around the house and bring the armor stands along
+  include_air      = false
+  include_entities = true
+}
+
+# A vanilla structure block export
+resource "minecraft_schematic" "well" {
+  path = "${path.module}/well.nbt"
+  position = {
+    x = 130
+    y = 64
+    z = -30
+  }
+
+  mirror = "x"
+}
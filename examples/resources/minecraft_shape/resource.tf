@@ -0,0 +1,65 @@
+# A glass dome: a hollow sphere of radius 8 centred on the floor
+resource "minecraft_shape" "dome" {
+  type     = "sphere"
+  material = "minecraft:glass"
+  position = {
+    x = -198
+    y = 66
+    z = -195
+  }
+
+  radius = 8
+  hollow = true
+}
+
+# A stone tower: a hollow cylinder 20 blocks high with two-block thick walls
+resource "minecraft_shape" "tower" {
+  type     = "cylinder"
+  material = "minecraft:stone_bricks"
+  position = {
+    x = -170
+    y = 66
+    z = -195
+  }
+
+  radius    = 4
+  height    = 20
+  hollow    = true
+  thickness = 2
+}
+
+# A gold ring standing upright, facing east
+resource "minecraft_shape" "portal" {
+  type     = "torus"
+  material = "minecraft:gold_block"
+  position = {
+    x = -150
+    y = 76
+    z = -195
+  }
+
+  radius       = 8
+  minor_radius = 1
+  axis         = "x"
+}
+
+# A line of iron blocks from the tower to the portal
+resource "minecraft_shape" "rope" {
+  type     = "line"
+  material = "minecraft:iron_block"
+  position = {
+    x = -170
+    y = 86
+    z = -195
+  }
+
+  end = {
+    x = -150
+    y = 84
+    z = -195
+  }
+}
+
+output "dome_blocks" {
+  value = minecraft_shape.dome.voxel_count
+}
@@ -0,0 +1,40 @@
+# A standing sign facing south-east
+resource "minecraft_sign" "welcome" {
+  wood     = "oak"
+  rotation = 14
+
+  position = {
+    x = -198
+    y = 66
+    z = -192
+  }
+
+  front = {
+    lines = ["Welcome to", "the hub!"]
+    color = "blue"
+  }
+
+  back = {
+    lines   = ["Spawn is", "this way"]
+    glowing = true
+  }
+
+  waxed = true
+}
+
+# A hanging cherry sign on a wall
+resource "minecraft_sign" "shop" {
+  wood      = "cherry"
+  placement = "wall_hanging"
+  facing    = "north"
+
+  position = {
+    x = -195
+    y = 67
+    z = -192
+  }
+
+  front = {
+    lines = ["", "Shop"]
+  }
+}
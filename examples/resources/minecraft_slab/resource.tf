@@ -0,0 +1,23 @@
+# A stone brick slab in the top half of the block
+resource "minecraft_slab" "ledge" {
+  material = "minecraft:stone_brick_slab"
+  position = {
+    x = -198
+    y = 66
+    z = -195
+  }
+
+  type = "top"
+}
+
+# A waterlogged bottom slab
+resource "minecraft_slab" "dock" {
+  material = "minecraft:oak_slab"
+  position = {
+    x = -197
+    y = 62
+    z = -195
+  }
+
+  waterlogged = true
+}
@@ -0,0 +1,30 @@
+# An igloo from the vanilla templates
+resource "minecraft_structure" "igloo" {
+  structure = "minecraft:igloo/top"
+  position = {
+    x = 40
+    y = 64
+    z = -20
+  }
+}
+
+# A half-ruined cottage from a datapack, facing east
+resource "minecraft_structure" "ruin" {
+  structure = "mypack:houses/cottage"
+  position = {
+    x = 100
+    y = 70
+    z = 100
+  }
+
+  rotation  = 90
+  integrity = 0.6
+  seed      = 1234
+
+  # Skips the structure block probe that reads the size
+  size = {
+    x = 7
+    y = 6
+    z = 9
+  }
+}
@@ -0,0 +1,38 @@
+# Rolling hills around a lake, 128x128 blocks, up to 24 blocks high
+resource "minecraft_terrain" "island" {
+  path = "${path.module}/heightmap.png"
+  position = {
+    x = -300
+    y = 60
+    z = -300
+  }
+
+  width      = 128
+  length     = 128
+  max_height = 24
+  sea_level  = 64
+}
+
+# A desert: sand over sandstone
+resource "minecraft_terrain" "dunes" {
+  path = "${path.module}/dunes.png"
+  position = {
+    x = -160
+    y = 60
+    z = -300
+  }
+
+  width      = 96
+  length     = 64
+  max_height = 12
+
+  layers = [
+    {
+      material = "minecraft:sand"
+      depth    = 3
+    },
+    {
+      material = "minecraft:sandstone"
+    },
+  ]
+}
@@ -0,0 +1,12 @@
+# A spruce trapdoor in the top half of the block, hinged on the south edge
+resource "minecraft_trapdoor" "hatch" {
+  material = "minecraft:spruce_trapdoor"
+  position = {
+    x = -198
+    y = 66
+    z = -195
+  }
+
+  facing = "north"
+  half   = "top"
+}
@@ -0,0 +1,24 @@
+# A castle modelled in MagicaVoxel, turned to face east
+resource "minecraft_voxel_model" "castle" {
+  path = "${path.module}/castle.vox"
+  position = {
+    x = -250
+    y = 64
+    z = -150
+  }
+
+  rotation = 90
+
+  # Pick real blocks for the main colours; the rest are matched to concrete
+  materials = {
+    "1"  = "minecraft:stone_bricks"
+    "2"  = "minecraft:mossy_stone_bricks"
+    "9"  = "minecraft:oak_planks"
+    "79" = "minecraft:air" # helper voxels
+  }
+  palette = "concrete"
+}
+
+output "castle_size" {
+  value = minecraft_voxel_model.castle.size
+}
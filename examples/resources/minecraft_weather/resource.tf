@@ -0,0 +1,5 @@
+# Clear skies for the build event, for as long as the resource exists
+resource "minecraft_weather" "default" {
+  weather = "clear"
+  locked  = true
+}
@@ -0,0 +1,9 @@
+# New players appear in the hub, facing north
+resource "minecraft_world_spawn" "default" {
+  position = {
+    x = 0
+    y = 64
+    z = 0
+  }
+  angle = 180
+}
@@ -0,0 +1,13 @@
+# A 2000 block wide world around spawn, shrinking to it over ten minutes
+resource "minecraft_worldborder" "default" {
+  center = {
+    x = 0
+    z = 0
+  }
+  size          = 2000
+  lerp_duration = 600
+
+  damage_amount    = 1
+  damage_buffer    = 2
+  warning_distance = 16
+}
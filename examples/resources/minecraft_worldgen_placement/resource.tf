@@ -0,0 +1,38 @@
+# A big oak by the spawn
+resource "minecraft_worldgen_placement" "oak" {
+  type = "feature"
+  name = "minecraft:fancy_oak"
+  position = {
+    x = 12
+    y = 64
+    z = -4
+  }
+}
+
+# A plains village grown from its town centre, cleared to air on destroy;
+# recording an area this large needs `world_path` on the provider
+resource "minecraft_worldgen_placement" "village" {
+  type   = "jigsaw"
+  pool   = "minecraft:village/plains/town_centers"
+  target = "minecraft:bottom"
+  depth  = 5
+  position = {
+    x = 300
+    y = 64
+    z = 300
+  }
+
+  radius  = 64
+  restore = false
+}
+
+# A desert pyramid, put back as it was on destroy
+resource "minecraft_worldgen_placement" "pyramid" {
+  type = "structure"
+  name = "minecraft:desert_pyramid"
+  position = {
+    x = -200
+    y = 70
+    z = 150
+  }
+}
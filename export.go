@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/anvil"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/export"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+const exportUsage = `Usage: terraform-provider-minecraft export --region x1,y1,z1:x2,y2,z2 [options]
+
+Captures an area of a world, read from its region files or over RCON, as
+Terraform configuration (minecraft_blocks and minecraft_block resources, with
+import blocks for the latter) or as a Sponge schematic for minecraft_schematic.
+
+Options:
+`
+
+var resourceName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// runExport implements the export subcommand.
+func runExport(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), exportUsage)
+		flags.PrintDefaults()
+	}
+
+	region := flags.String("region", "", "area to export, as two opposite corners `x1,y1,z1:x2,y2,z2`")
+	format := flags.String("format", "hcl", "output `format`: hcl or schem")
+	output := flags.String("output", "", "file to write; defaults to standard output for hcl and export.schem for schem")
+	name := flags.String("name", "export", "resource name for hcl output")
+	dimension := flags.String("dimension", "", "dimension to read from, e.g. minecraft:the_nether; defaults to the overworld")
+	includeAir := flags.Bool("include-air", false, "keep air blocks in hcl output, so applying it clears the area")
+	imports := flags.Bool("imports", true, "write import blocks (Terraform 1.5 and later) for minecraft_block resources in hcl output")
+	address := flags.String("address", os.Getenv("MINECRAFT_ADDRESS"), "RCON address of the server; defaults to MINECRAFT_ADDRESS")
+	password := flags.String("password", os.Getenv("MINECRAFT_PASSWORD"), "RCON password of the server; defaults to MINECRAFT_PASSWORD")
+	worldPath := flags.String("world", os.Getenv("MINECRAFT_WORLD_PATH"), "world directory to read region files from instead of probing over RCON; defaults to MINECRAFT_WORLD_PATH")
+	serverVersion := flags.String("server-version", os.Getenv("MINECRAFT_VERSION"), "Minecraft version of the server; defaults to MINECRAFT_VERSION or the latest supported release")
+	quiet := flags.Bool("quiet", false, "don't report progress")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+
+	if *region == "" {
+		return fmt.Errorf("--region is required")
+	}
+	box, err := minecraft.ParseBox(*region)
+	if err != nil {
+		return err
+	}
+	if *format != "hcl" && *format != "schem" {
+		return fmt.Errorf("--format must be hcl or schem, got %q", *format)
+	}
+	if !resourceName.MatchString(*name) {
+		return fmt.Errorf("--name %q is not a valid resource name", *name)
+	}
+	if *dimension != "" {
+		if err := minecraft.ValidateDimension(*dimension); err != nil {
+			return fmt.Errorf("--dimension: %w", err)
+		}
+		*dimension = minecraft.NormalizeDimension(*dimension)
+	}
+	version, err := minecraft.ParseVersion(*serverVersion)
+	if err != nil {
+		return err
+	}
+	registry, err := minecraft.BlockStatesFor(version)
+	if err != nil {
+		return err
+	}
+
+	var source export.Source
+	switch {
+	case *worldPath != "":
+		world, err := anvil.Open(*worldPath)
+		if err != nil {
+			return err
+		}
+		// Have a running server save first so the files are current.
+		if *address != "" && *password != "" {
+			client, err := minecraft.New(*address, *password)
+			if err != nil {
+				return fmt.Errorf("connect to %s: %w", *address, err)
+			}
+			if err := client.SaveAll(ctx); err != nil {
+				return fmt.Errorf("save the world: %w", err)
+			}
+		}
+		source = export.NewWorld(world.Dimension(*dimension))
+	case *address != "" && *password != "":
+		client, err := minecraft.New(*address, *password)
+		if err != nil {
+			return fmt.Errorf("connect to %s: %w", *address, err)
+		}
+		source = export.NewRCON(client.InDimension(*dimension), registry)
+	default:
+		return fmt.Errorf("--world, or --address and --password (MINECRAFT_ADDRESS and MINECRAFT_PASSWORD), are required")
+	}
+
+	var progress func(done, total int)
+	if !*quiet {
+		progress = func(done, total int) {
+			if done%256 == 0 || done == total {
+				fmt.Fprintf(os.Stderr, "\rRead %d of %d blocks", done, total)
+			}
+			if done == total {
+				fmt.Fprintln(os.Stderr)
+			}
+		}
+	}
+
+	r, err := export.Read(ctx, source, box, progress)
+	if err != nil {
+		return err
+	}
+	if len(r.Unknown) > 0 {
+		fmt.Fprintf(os.Stderr, "Skipped %d blocks that could not be identified, starting at %s\n", len(r.Unknown), r.Unknown[0])
+	}
+
+	if *format == "schem" {
+		path := *output
+		if path == "" {
+			path = "export.schem"
+		}
+		return minecraft.WriteSchematic(path, r.Schematic(version))
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return export.WriteHCL(w, r, export.HCLOptions{
+		Name:       *name,
+		Dimension:  *dimension,
+		IncludeAir: *includeAir,
+		Imports:    *imports,
+	})
+}
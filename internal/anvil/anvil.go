@@ -0,0 +1,218 @@
+// Package anvil reads blocks, block entities and biomes straight from a
+// world's Anvil region files (.mca), for when the provider runs on the same
+// host as the server. Reading files is much faster than probing blocks over
+// RCON, but only sees what the server has saved.
+//
+// Worlds saved by Minecraft 1.13 and later are supported. Biome lookups need
+// 1.18 or later, when biomes moved into chunk sections by name.
+package anvil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// ErrNotGenerated is returned for positions in chunks the server has not
+// generated or saved yet.
+var ErrNotGenerated = errors.New("chunk has not been generated")
+
+// sectorSize is the unit region files are allocated in.
+const sectorSize = 4096
+
+// World is a world save directory, the one holding level.dat.
+type World struct {
+	path string
+}
+
+// Open checks path is a world directory.
+func Open(path string) (*World, error) {
+	if _, err := os.Stat(filepath.Join(path, "level.dat")); err != nil {
+		return nil, fmt.Errorf("%s is not a world directory: %w", path, err)
+	}
+	return &World{path: path}, nil
+}
+
+// Level returns the `Data` compound of level.dat, which holds world-wide
+// settings such as the spawn point, difficulty, weather and world border.
+func (w *World) Level() (nbt.Compound, error) {
+	_, root, err := nbt.ReadFile(filepath.Join(w.path, "level.dat"))
+	if err != nil {
+		return nil, err
+	}
+	data, ok := root["Data"].(nbt.Compound)
+	if !ok {
+		return nil, fmt.Errorf("level.dat has no Data compound")
+	}
+	return data, nil
+}
+
+// Dimension returns a reader for one dimension, e.g. "minecraft:the_nether".
+// An empty name is the overworld. Datapack dimensions are read from
+// `dimensions/<namespace>/<path>`.
+func (w *World) Dimension(name string) *Dimension {
+	dir := filepath.Join(w.path, "region")
+	switch name {
+	case "", "minecraft:overworld":
+	case "minecraft:the_nether":
+		dir = filepath.Join(w.path, "DIM-1", "region")
+	case "minecraft:the_end":
+		dir = filepath.Join(w.path, "DIM1", "region")
+	default:
+		ns, path := "minecraft", name
+		if i := strings.Index(name, ":"); i >= 0 {
+			ns, path = name[:i], name[i+1:]
+		}
+		dir = filepath.Join(w.path, "dimensions", ns, filepath.FromSlash(path), "region")
+	}
+	return &Dimension{dir: dir, chunks: map[[2]int]*chunk{}}
+}
+
+// Dimension reads one dimension's region files. Chunks are cached once
+// read, so a Dimension should not outlive the lookups it was made for. It
+// is safe for concurrent use.
+type Dimension struct {
+	dir string
+
+	mu     sync.Mutex
+	chunks map[[2]int]*chunk // nil entries are chunks that don't exist
+}
+
+// Block returns the full block state at the given position, e.g.
+// `minecraft:oak_log[axis=x]`. Positions above or below the saved sections
+// are air.
+func (d *Dimension) Block(x, y, z int) (string, error) {
+	c, err := d.chunk(x>>4, z>>4)
+	if err != nil {
+		return "", err
+	}
+	return c.block(x, y, z)
+}
+
+// BlockEntity returns the block entity data at the given position without
+// its ID or position, or nil if there is none.
+func (d *Dimension) BlockEntity(x, y, z int) (nbt.Compound, error) {
+	c, err := d.chunk(x>>4, z>>4)
+	if err != nil {
+		return nil, err
+	}
+	return c.blockEntities[minecraft.Pos{X: x, Y: y, Z: z}], nil
+}
+
+// Biome returns the biome at the given position, e.g. `minecraft:plains`.
+// Biomes are stored per 4×4×4 cell.
+func (d *Dimension) Biome(x, y, z int) (string, error) {
+	c, err := d.chunk(x>>4, z>>4)
+	if err != nil {
+		return "", err
+	}
+	return c.biome(x, y, z)
+}
+
+// HeightRange returns the lowest and highest block Y the chunk holding the
+// given column has sections for, e.g. -64 and 319 for a 1.18 overworld.
+func (d *Dimension) HeightRange(x, z int) (int, int, error) {
+	c, err := d.chunk(x>>4, z>>4)
+	if err != nil {
+		return 0, 0, err
+	}
+	return c.minY, c.maxY, nil
+}
+
+func (d *Dimension) chunk(cx, cz int) (*chunk, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := [2]int{cx, cz}
+	if c, ok := d.chunks[key]; ok {
+		if c == nil {
+			return nil, fmt.Errorf("%w: chunk %d, %d", ErrNotGenerated, cx, cz)
+		}
+		return c, nil
+	}
+
+	root, err := d.readChunk(cx, cz)
+	if errors.Is(err, ErrNotGenerated) {
+		d.chunks[key] = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c, err := parseChunk(root)
+	if err != nil {
+		return nil, fmt.Errorf("chunk %d, %d: %w", cx, cz, err)
+	}
+	d.chunks[key] = c
+	return c, nil
+}
+
+// readChunk reads a chunk's root compound from its region file.
+func (d *Dimension) readChunk(cx, cz int) (nbt.Compound, error) {
+	path := filepath.Join(d.dir, fmt.Sprintf("r.%d.%d.mca", cx>>5, cz>>5))
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: chunk %d, %d", ErrNotGenerated, cx, cz)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// The header starts with a 4-byte location per chunk: a 3-byte sector
+	// offset and a 1-byte sector count.
+	var location [4]byte
+	if _, err := f.ReadAt(location[:], int64(4*((cx&31)+(cz&31)*32))); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	offset := int64(location[0])<<16 | int64(location[1])<<8 | int64(location[2])
+	if offset == 0 || location[3] == 0 {
+		return nil, fmt.Errorf("%w: chunk %d, %d", ErrNotGenerated, cx, cz)
+	}
+
+	// Each chunk starts with its length and compression type.
+	var head [5]byte
+	if _, err := f.ReadAt(head[:], offset*sectorSize); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	length := int64(binary.BigEndian.Uint32(head[:4]))
+	if length < 1 || length > int64(location[3])*sectorSize {
+		return nil, fmt.Errorf("read %s: chunk %d, %d has invalid length %d", path, cx, cz, length)
+	}
+
+	var data io.Reader
+	compression := head[4]
+	if compression&0x80 != 0 {
+		// Chunks too large for the region file live in c.<x>.<z>.mcc.
+		external, err := os.ReadFile(filepath.Join(d.dir, fmt.Sprintf("c.%d.%d.mcc", cx, cz)))
+		if err != nil {
+			return nil, err
+		}
+		data = bytes.NewReader(external)
+		compression &^= 0x80
+	} else {
+		data = io.NewSectionReader(f, offset*sectorSize+5, length-1)
+	}
+
+	// nbt.Read detects gzip (1) and zlib (2); 3 is uncompressed.
+	switch compression {
+	case 1, 2, 3:
+	case 4:
+		return nil, fmt.Errorf("read %s: chunk %d, %d is LZ4 compressed, which is not supported; set region-file-compression=deflate in server.properties", path, cx, cz)
+	default:
+		return nil, fmt.Errorf("read %s: chunk %d, %d has unknown compression type %d", path, cx, cz, compression)
+	}
+	_, root, err := nbt.Read(data)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: chunk %d, %d: %w", path, cx, cz, err)
+	}
+	return root, nil
+}
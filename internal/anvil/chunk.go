@@ -0,0 +1,244 @@
+package anvil
+
+import (
+	"fmt"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// Data versions where the chunk format changed.
+const (
+	dataVersionFlattening = 1451 // 1.13: block state palettes
+	dataVersionPadded     = 2527 // 1.16: packed entries no longer span longs
+	dataVersionNoLevel    = 2844 // 1.18: sections at the root, biomes by name
+)
+
+// chunk is a decoded 16×16 column of sections.
+type chunk struct {
+	sections      map[int]*section // by section Y, which is block Y / 16
+	blockEntities map[minecraft.Pos]nbt.Compound
+	hasBiomes     bool
+	minY, maxY    int
+}
+
+// section is a 16×16×16 cube of blocks, stored as a palette and indexes
+// into it packed into longs.
+type section struct {
+	blocks    packed
+	biomes    packed
+	hasBiomes bool
+}
+
+type packed struct {
+	palette []string
+	data    []int64
+	bits    int
+	spans   bool // entries may straddle two longs (before 1.16)
+}
+
+func parseChunk(root nbt.Compound) (*chunk, error) {
+	dataVersion, _ := number(root["DataVersion"])
+	if dataVersion < dataVersionFlattening {
+		return nil, fmt.Errorf("worlds saved before Minecraft 1.13 (data version %d) are not supported", dataVersion)
+	}
+
+	// Before 1.18 everything sat in a "Level" compound, under other names.
+	level, sectionsKey, blockEntitiesKey := root, "sections", "block_entities"
+	if dataVersion < dataVersionNoLevel {
+		level, _ = root["Level"].(nbt.Compound)
+		sectionsKey, blockEntitiesKey = "Sections", "TileEntities"
+	}
+
+	c := &chunk{
+		sections:      map[int]*section{},
+		blockEntities: map[minecraft.Pos]nbt.Compound{},
+		hasBiomes:     dataVersion >= dataVersionNoLevel,
+	}
+
+	sections, _ := level[sectionsKey].(nbt.List)
+	first := true
+	for _, item := range sections.Items {
+		tag, ok := item.(nbt.Compound)
+		if !ok {
+			continue
+		}
+		y, _ := number(tag["Y"])
+
+		s := &section{}
+		if dataVersion >= dataVersionNoLevel {
+			states, ok := tag["block_states"].(nbt.Compound)
+			if !ok {
+				continue // a lighting-only section above or below the world
+			}
+			s.blocks = packed{palette: blockPalette(states["palette"]), bits: 4}
+			s.blocks.data, _ = states["data"].([]int64)
+			if biomes, ok := tag["biomes"].(nbt.Compound); ok {
+				list, _ := biomes["palette"].(nbt.List)
+				for _, b := range list.Items {
+					name, _ := b.(string)
+					s.biomes.palette = append(s.biomes.palette, name)
+				}
+				s.biomes.data, _ = biomes["data"].([]int64)
+				s.hasBiomes = len(s.biomes.palette) > 0
+			}
+		} else {
+			if tag["Palette"] == nil {
+				continue
+			}
+			s.blocks = packed{palette: blockPalette(tag["Palette"]), bits: 4, spans: dataVersion < dataVersionPadded}
+			s.blocks.data, _ = tag["BlockStates"].([]int64)
+		}
+		if len(s.blocks.palette) == 0 {
+			continue
+		}
+		s.blocks.bits = maxInt(4, bitsFor(len(s.blocks.palette)))
+		s.biomes.bits = bitsFor(len(s.biomes.palette))
+
+		c.sections[y] = s
+		if first || y*16 < c.minY {
+			c.minY = y * 16
+		}
+		if first || y*16+15 > c.maxY {
+			c.maxY = y*16 + 15
+		}
+		first = false
+	}
+
+	blockEntities, _ := level[blockEntitiesKey].(nbt.List)
+	for _, item := range blockEntities.Items {
+		tag, ok := item.(nbt.Compound)
+		if !ok {
+			continue
+		}
+		x, okX := number(tag["x"])
+		y, okY := number(tag["y"])
+		z, okZ := number(tag["z"])
+		if !okX || !okY || !okZ {
+			continue
+		}
+		data := nbt.Compound{}
+		for k, v := range tag {
+			switch k {
+			case "id", "x", "y", "z", "keepPacked":
+			default:
+				data[k] = v
+			}
+		}
+		c.blockEntities[minecraft.Pos{X: x, Y: y, Z: z}] = data
+	}
+	return c, nil
+}
+
+func (c *chunk) block(x, y, z int) (string, error) {
+	s, ok := c.sections[y>>4]
+	if !ok {
+		return "minecraft:air", nil
+	}
+	block, err := s.blocks.get((y&15)<<8 | (z&15)<<4 | (x & 15))
+	if err != nil {
+		return "", fmt.Errorf("block at %d %d %d: %w", x, y, z, err)
+	}
+	return block, nil
+}
+
+func (c *chunk) biome(x, y, z int) (string, error) {
+	if !c.hasBiomes {
+		return "", fmt.Errorf("biome lookups need a world saved by Minecraft 1.18 or later")
+	}
+	s, ok := c.sections[y>>4]
+	if !ok || !s.hasBiomes {
+		return "", fmt.Errorf("no biome data at %d %d %d", x, y, z)
+	}
+	biome, err := s.biomes.get((y&15)>>2<<4 | (z&15)>>2<<2 | (x&15)>>2)
+	if err != nil {
+		return "", fmt.Errorf("biome at %d %d %d: %w", x, y, z, err)
+	}
+	return biome, nil
+}
+
+// get returns the palette entry for the i-th packed index.
+func (p packed) get(i int) (string, error) {
+	if len(p.palette) == 1 || len(p.data) == 0 {
+		return p.palette[0], nil
+	}
+
+	mask := uint64(1)<<uint(p.bits) - 1
+	var v uint64
+	if p.spans {
+		bit := i * p.bits
+		word, offset := bit/64, uint(bit%64)
+		if word >= len(p.data) {
+			return "", fmt.Errorf("index %d is past the end of the packed data", i)
+		}
+		v = uint64(p.data[word]) >> offset
+		if int(offset)+p.bits > 64 {
+			if word+1 >= len(p.data) {
+				return "", fmt.Errorf("index %d is past the end of the packed data", i)
+			}
+			v |= uint64(p.data[word+1]) << (64 - offset)
+		}
+	} else {
+		perLong := 64 / p.bits
+		word := i / perLong
+		if word >= len(p.data) {
+			return "", fmt.Errorf("index %d is past the end of the packed data", i)
+		}
+		v = uint64(p.data[word]) >> uint(i%perLong*p.bits)
+	}
+
+	index := int(v & mask)
+	if index >= len(p.palette) {
+		return "", fmt.Errorf("palette index %d is out of range", index)
+	}
+	return p.palette[index], nil
+}
+
+// blockPalette decodes a list of {Name, Properties} compounds.
+func blockPalette(v interface{}) []string {
+	list, _ := v.(nbt.List)
+	palette := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		tag, _ := item.(nbt.Compound)
+		name, _ := tag["Name"].(string)
+		state := map[string]string{}
+		props, _ := tag["Properties"].(nbt.Compound)
+		for k, v := range props {
+			if s, ok := v.(string); ok {
+				state[k] = s
+			}
+		}
+		palette = append(palette, minecraft.BlockWithState(name, state))
+	}
+	return palette
+}
+
+// bitsFor returns the bits needed to index n palette entries.
+func bitsFor(n int) int {
+	bits := 0
+	for 1<<uint(bits) < n {
+		bits++
+	}
+	return bits
+}
+
+func number(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,167 @@
+// Package export captures an area of a world so it can be managed as code:
+// as minecraft_blocks and minecraft_block resources, or as a Sponge
+// schematic for minecraft_schematic.
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/anvil"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// Source reads blocks from a world.
+type Source interface {
+	// Block returns the full block state at p, e.g.
+	// `minecraft:oak_stairs[facing=east,half=bottom,shape=straight,waterlogged=false]`,
+	// and its block entity data, if any, without ID or position.
+	Block(ctx context.Context, p minecraft.Pos) (string, nbt.Compound, error)
+}
+
+// Region is a captured box of blocks, in world positions.
+type Region struct {
+	Box           minecraft.Box
+	Blocks        map[minecraft.Pos]string
+	BlockEntities map[minecraft.Pos]nbt.Compound
+
+	// Unknown lists positions whose block could not be identified, e.g.
+	// modded blocks read over RCON or chunks missing from the world files.
+	// They are left out of Blocks.
+	Unknown []minecraft.Pos
+}
+
+// Read captures every block in box. progress, if not nil, is called after
+// each block with the number read so far and the total.
+func Read(ctx context.Context, src Source, box minecraft.Box, progress func(done, total int)) (*Region, error) {
+	r := &Region{
+		Box:           box,
+		Blocks:        map[minecraft.Pos]string{},
+		BlockEntities: map[minecraft.Pos]nbt.Compound{},
+	}
+
+	total, done := box.Volume(), 0
+	for y := box.Min.Y; y <= box.Max.Y; y++ {
+		for z := box.Min.Z; z <= box.Max.Z; z++ {
+			for x := box.Min.X; x <= box.Max.X; x++ {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+
+				p := minecraft.Pos{X: x, Y: y, Z: z}
+				block, data, err := src.Block(ctx, p)
+				switch {
+				case errors.Is(err, minecraft.ErrUnknownBlock), errors.Is(err, anvil.ErrNotGenerated):
+					r.Unknown = append(r.Unknown, p)
+				case err != nil:
+					return nil, fmt.Errorf("read block at %s: %w", p, err)
+				default:
+					r.Blocks[p] = block
+					if len(data) > 0 {
+						r.BlockEntities[p] = data
+					}
+				}
+
+				done++
+				if progress != nil {
+					progress(done, total)
+				}
+			}
+		}
+	}
+	return r, nil
+}
+
+// Schematic returns the region as a schematic whose origin is the box's
+// minimum corner, tagged with the data version of the server it came from.
+func (r *Region) Schematic(version minecraft.Version) *minecraft.Schematic {
+	min := r.Box.Min
+	s := &minecraft.Schematic{
+		DataVersion:   minecraft.DataVersionFor(version),
+		Size:          minecraft.Pos{X: r.Box.Max.X - min.X + 1, Y: r.Box.Max.Y - min.Y + 1, Z: r.Box.Max.Z - min.Z + 1},
+		Blocks:        make(map[minecraft.Pos]string, len(r.Blocks)),
+		BlockEntities: make(map[minecraft.Pos]nbt.Compound, len(r.BlockEntities)),
+	}
+	for p, block := range r.Blocks {
+		s.Blocks[minecraft.Pos{X: p.X - min.X, Y: p.Y - min.Y, Z: p.Z - min.Z}] = block
+	}
+	for p, data := range r.BlockEntities {
+		s.BlockEntities[minecraft.Pos{X: p.X - min.X, Y: p.Y - min.Y, Z: p.Z - min.Z}] = data
+	}
+	return s
+}
+
+// RCON reads blocks by probing the server with `execute if block`. It costs
+// several round-trips per block, so it suits small areas. The blocks found
+// most recently are tried first, which makes runs of the same block cheap.
+type RCON struct {
+	client   minecraft.Client
+	registry minecraft.BlockStates
+	recent   []string
+}
+
+// recentBlocks is how many block IDs RCON remembers as hints.
+const recentBlocks = 8
+
+// NewRCON returns a source reading through client, identifying blocks from
+// registry.
+func NewRCON(client minecraft.Client, registry minecraft.BlockStates) *RCON {
+	return &RCON{client: client, registry: registry}
+}
+
+// Block implements Source.
+func (s *RCON) Block(ctx context.Context, p minecraft.Pos) (string, nbt.Compound, error) {
+	snapshot, err := s.client.CaptureBlock(ctx, s.registry, s.recent, p.X, p.Y, p.Z)
+	if err != nil {
+		return "", nil, err
+	}
+
+	id, _ := minecraft.ParseBlock(snapshot.Block)
+	s.remember(id)
+
+	if snapshot.NBT == "" {
+		return snapshot.Block, nil, nil
+	}
+	data, err := minecraft.BlockEntityData(snapshot.NBT)
+	if err != nil {
+		return "", nil, fmt.Errorf("parse block data: %w", err)
+	}
+	return snapshot.Block, data, nil
+}
+
+// remember moves id to the front of the recent blocks.
+func (s *RCON) remember(id string) {
+	recent := []string{id}
+	for _, r := range s.recent {
+		if r != id && len(recent) < recentBlocks {
+			recent = append(recent, r)
+		}
+	}
+	s.recent = recent
+}
+
+// World reads blocks from a dimension's region files, which is fast enough
+// for large areas but only sees what the server has saved.
+type World struct {
+	dimension *anvil.Dimension
+}
+
+// NewWorld returns a source reading from dimension.
+func NewWorld(dimension *anvil.Dimension) *World {
+	return &World{dimension: dimension}
+}
+
+// Block implements Source.
+func (s *World) Block(ctx context.Context, p minecraft.Pos) (string, nbt.Compound, error) {
+	block, err := s.dimension.Block(p.X, p.Y, p.Z)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := s.dimension.BlockEntity(p.X, p.Y, p.Z)
+	if err != nil {
+		return "", nil, err
+	}
+	return block, data, nil
+}
@@ -0,0 +1,161 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// HCLOptions control how a region is written as Terraform configuration.
+type HCLOptions struct {
+	// Name is the resource name of the minecraft_blocks resource and the
+	// prefix of the minecraft_block resources.
+	Name string
+
+	// Dimension is written to every resource when set.
+	Dimension string
+
+	// IncludeAir keeps air blocks in minecraft_blocks, so applying the
+	// configuration clears whatever has been built there since.
+	IncludeAir bool
+
+	// Imports adds an `import` block (Terraform 1.5 and later) for every
+	// minecraft_block resource, so `terraform apply` adopts the blocks
+	// instead of placing them again. minecraft_blocks cannot be imported;
+	// applying it sets blocks that are already in place, which is harmless.
+	Imports bool
+}
+
+// WriteHCL writes the region as one minecraft_blocks resource holding the
+// plain blocks, and a minecraft_block resource for each block with block
+// entity data such as a chest's contents or a sign's text.
+func WriteHCL(w io.Writer, r *Region, opts HCLOptions) error {
+	if opts.Name == "" {
+		opts.Name = "export"
+	}
+
+	blocks := map[minecraft.Pos]string{}
+	for p, block := range r.Blocks {
+		if _, ok := r.BlockEntities[p]; ok {
+			continue
+		}
+		if !opts.IncludeAir && isAir(block) {
+			continue
+		}
+		blocks[p] = block
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# Exported from %s to %s by `terraform-provider-minecraft export`.\n", r.Box.Min, r.Box.Max)
+
+	var imports [][2]string
+	if len(blocks) > 0 {
+		positions := minecraft.SortedPositions(blocks)
+		entries := make([][2]string, len(positions))
+		for i, p := range positions {
+			entries[i] = [2]string{hclString(p.String()), hclString(blocks[p])}
+		}
+
+		fmt.Fprintf(bw, "\nresource \"minecraft_blocks\" %s {\n", hclString(opts.Name))
+		if opts.Dimension != "" {
+			fmt.Fprintf(bw, "  dimension = %s\n\n", hclString(opts.Dimension))
+		}
+		bw.WriteString("  blocks = {\n")
+		writeAttributes(bw, "    ", entries)
+		bw.WriteString("  }\n}\n")
+	}
+
+	for _, p := range minecraft.SortedPositions(blockEntityBlocks(r)) {
+		name := fmt.Sprintf("%s_%s_%s_%s", opts.Name, coord(p.X), coord(p.Y), coord(p.Z))
+		material, state := minecraft.ParseBlock(r.Blocks[p])
+
+		fmt.Fprintf(bw, "\nresource \"minecraft_block\" %s {\n", hclString(name))
+		attrs := [][2]string{{"material", hclString(material)}}
+		if opts.Dimension != "" {
+			attrs = append(attrs, [2]string{"dimension", hclString(opts.Dimension)})
+		}
+		attrs = append(attrs, [2]string{"nbt", hclString(nbt.Stringify(r.BlockEntities[p]))})
+		writeAttributes(bw, "  ", attrs)
+		if len(state) > 0 {
+			bw.WriteString("  state = {\n")
+			var entries [][2]string
+			for _, key := range sortedStateKeys(state) {
+				entries = append(entries, [2]string{key, hclString(state[key])})
+			}
+			writeAttributes(bw, "    ", entries)
+			bw.WriteString("  }\n")
+		}
+		fmt.Fprintf(bw, "\n  position = {\n    x = %d\n    y = %d\n    z = %d\n  }\n}\n", p.X, p.Y, p.Z)
+
+		id := fmt.Sprintf("block-%d-%d-%d", p.X, p.Y, p.Z)
+		if opts.Dimension != "" {
+			id += "@" + opts.Dimension
+		}
+		imports = append(imports, [2]string{"minecraft_block." + name, id})
+	}
+
+	if opts.Imports {
+		for _, imp := range imports {
+			fmt.Fprintf(bw, "\nimport {\n  to = %s\n  id = %s\n}\n", imp[0], hclString(imp[1]))
+		}
+	}
+	return bw.Flush()
+}
+
+// blockEntityBlocks returns the blocks that carry block entity data.
+func blockEntityBlocks(r *Region) map[minecraft.Pos]string {
+	out := make(map[minecraft.Pos]string, len(r.BlockEntities))
+	for p := range r.BlockEntities {
+		out[p] = r.Blocks[p]
+	}
+	return out
+}
+
+// writeAttributes writes `key = value` lines with the equals signs aligned,
+// as `terraform fmt` does.
+func writeAttributes(w *bufio.Writer, indent string, attrs [][2]string) {
+	width := 0
+	for _, a := range attrs {
+		if len(a[0]) > width {
+			width = len(a[0])
+		}
+	}
+	for _, a := range attrs {
+		fmt.Fprintf(w, "%s%-*s = %s\n", indent, width, a[0], a[1])
+	}
+}
+
+// hclString quotes s as an HCL string, escaping template sequences.
+func hclString(s string) string {
+	q := strconv.Quote(s)
+	q = strings.ReplaceAll(q, "${", "$${")
+	return strings.ReplaceAll(q, "%{", "%%{")
+}
+
+// coord renders a coordinate for a resource name, spelling a minus as "n".
+func coord(n int) string {
+	if n < 0 {
+		return "n" + strconv.Itoa(-n)
+	}
+	return strconv.Itoa(n)
+}
+
+func isAir(block string) bool {
+	id, _ := minecraft.ParseBlock(block)
+	return id == "minecraft:air" || id == "minecraft:cave_air" || id == "minecraft:void_air"
+}
+
+func sortedStateKeys(state map[string]string) []string {
+	keys := make([]string, 0, len(state))
+	for k := range state {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
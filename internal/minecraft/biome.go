@@ -0,0 +1,103 @@
+package minecraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// `fillbiome` arrived in 1.19.3.
+var fillBiomeVersion = MustParseVersion("1.19.3")
+
+// FillBiomeSupported reports whether the server has `fillbiome`.
+func FillBiomeSupported(version Version) bool {
+	return version.AtLeast(fillBiomeVersion)
+}
+
+// ValidateBiome checks that biome is a biome ID such as
+// `minecraft:cherry_grove`. Filters may also be a tag such as
+// `#minecraft:is_forest`.
+func ValidateBiome(biome string, tag bool) error {
+	if tag {
+		biome = strings.TrimPrefix(biome, "#")
+	}
+	if !resourceLocationPattern.MatchString(biome) {
+		return fmt.Errorf("biome must be a resource location such as `minecraft:cherry_grove` (got %q)", biome)
+	}
+	return nil
+}
+
+// FillBiome sets the biome in box. Biomes are stored per 4×4×4 cell, so the
+// game changes every cell the box touches and counts the volume of those
+// cells against its limit; the box is split on cell boundaries into
+// `fillbiome` commands of at most MaxFillVolume blocks of whole cells. A filter, a biome or `#tag`, limits
+// the change to cells that match it. It returns the number of commands sent.
+func (c Client) FillBiome(ctx context.Context, version Version, box Box, biome, filter string) (int, error) {
+	if !FillBiomeSupported(version) {
+		return 0, fmt.Errorf("fillbiome needs Minecraft %s or later", fillBiomeVersion)
+	}
+	if err := ValidateBiome(biome, false); err != nil {
+		return 0, err
+	}
+	if filter != "" {
+		if err := ValidateBiome(filter, true); err != nil {
+			return 0, err
+		}
+	}
+
+	cells := Box{
+		Min: Pos{box.Min.X >> 2, box.Min.Y >> 2, box.Min.Z >> 2},
+		Max: Pos{box.Max.X >> 2, box.Max.Y >> 2, box.Max.Z >> 2},
+	}
+	var pieces []Box
+	for _, c := range splitBox(cells, MaxFillVolume/64) {
+		pieces = append(pieces, Box{
+			Min: Pos{maxInt(c.Min.X<<2, box.Min.X), maxInt(c.Min.Y<<2, box.Min.Y), maxInt(c.Min.Z<<2, box.Min.Z)},
+			Max: Pos{minInt(c.Max.X<<2+3, box.Max.X), minInt(c.Max.Y<<2+3, box.Max.Y), minInt(c.Max.Z<<2+3, box.Max.Z)},
+		})
+	}
+	for i, piece := range pieces {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		command := fmt.Sprintf("fillbiome %d %d %d %d %d %d %s",
+			piece.Min.X, piece.Min.Y, piece.Min.Z, piece.Max.X, piece.Max.Y, piece.Max.Z, biome)
+		if filter != "" {
+			command += " replace " + filter
+		}
+		out, err := c.send(command)
+		if err != nil {
+			return i, err
+		}
+		// "N biome entries set between …"
+		if !strings.Contains(out, "set between") {
+			return i, fmt.Errorf("fillbiome %s to %s: %s", piece.Min, piece.Max, strings.TrimSpace(out))
+		}
+	}
+	return len(pieces), nil
+}
+
+// ErrNotLoaded is returned when a command needs a position in a chunk the
+// server does not have loaded.
+var ErrNotLoaded = errors.New("position is not loaded")
+
+// TestBiome reports whether the cell holding the given position is biome,
+// with `execute if biome`.
+func (c Client) TestBiome(ctx context.Context, biome string, x, y, z int) (bool, error) {
+	out, err := c.send(fmt.Sprintf("execute if biome %d %d %d %s", x, y, z, biome))
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case strings.Contains(out, "Test passed"):
+		return true, nil
+	case strings.Contains(out, "Test failed"):
+		return false, nil
+	case strings.Contains(out, "not loaded"):
+		return false, ErrNotLoaded
+	default:
+		return false, fmt.Errorf("unexpected response: %q", out)
+	}
+}
@@ -0,0 +1,222 @@
+package minecraft
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Curated registry of vanilla blocks and their block state properties.
+// Each block records the release it first appeared in so the registry can
+// be narrowed to the server version the provider talks to.
+//
+//go:embed data/blockstates.json
+var blockStatesJSON []byte
+
+// ErrUnknownBlock is returned when a block is not in the embedded registry,
+// e.g. a modded block or one newer than the registry.
+var ErrUnknownBlock = errors.New("block is not in the block state registry")
+
+type blockDefinition struct {
+	Since      string              `json:"since"`
+	Properties map[string][]string `json:"properties"`
+}
+
+var (
+	blockDefinitionsOnce sync.Once
+	blockDefinitions     map[string]blockDefinition
+	blockDefinitionsErr  error
+)
+
+func loadBlockDefinitions() (map[string]blockDefinition, error) {
+	blockDefinitionsOnce.Do(func() {
+		var doc struct {
+			Blocks map[string]blockDefinition `json:"blocks"`
+		}
+		if err := json.Unmarshal(blockStatesJSON, &doc); err != nil {
+			blockDefinitionsErr = fmt.Errorf("decode block state registry: %w", err)
+			return
+		}
+		blockDefinitions = doc.Blocks
+	})
+	return blockDefinitions, blockDefinitionsErr
+}
+
+// BlockStates maps block IDs to their state properties and allowed values
+// for a single server version.
+type BlockStates map[string]map[string][]string
+
+// BlockStatesFor returns the registry narrowed to blocks available in version.
+func BlockStatesFor(version Version) (BlockStates, error) {
+	defs, err := loadBlockDefinitions()
+	if err != nil {
+		return nil, err
+	}
+
+	states := BlockStates{}
+	for id, def := range defs {
+		if !version.AtLeast(MustParseVersion(def.Since)) {
+			continue
+		}
+		states[id] = def.Properties
+	}
+	return states, nil
+}
+
+// IDs returns the block IDs in the registry, sorted.
+func (b BlockStates) IDs() []string {
+	ids := make([]string, 0, len(b))
+	for id := range b {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Validate checks the properties in state against the registry entry for material.
+func (b BlockStates) Validate(material string, state map[string]string) error {
+	material = NormalizeMaterial(material)
+
+	props, ok := b[material]
+	if !ok {
+		if def, known := blockDefinitions[material]; known {
+			return fmt.Errorf("%s is not available before Minecraft %s", material, def.Since)
+		}
+		return fmt.Errorf("%w: %s", ErrUnknownBlock, material)
+	}
+
+	for _, key := range sortedKeys(state) {
+		allowed, ok := props[key]
+		if !ok {
+			if len(props) == 0 {
+				return fmt.Errorf("%s has no block state properties (got %q)", material, key)
+			}
+			return fmt.Errorf("%s has no property %q; expected one of: %s", material, key, strings.Join(sortedPropertyKeys(props), ", "))
+		}
+		if !containsString(allowed, state[key]) {
+			return fmt.Errorf("%s property %q must be one of: %s (got %q)", material, key, strings.Join(allowed, ", "), state[key])
+		}
+	}
+	return nil
+}
+
+// NormalizeMaterial adds the implicit `minecraft:` namespace to bare block IDs.
+func NormalizeMaterial(material string) string {
+	material = strings.TrimSpace(material)
+	if material != "" && !strings.Contains(material, ":") {
+		material = "minecraft:" + material
+	}
+	return material
+}
+
+// BlockWithState renders a block predicate such as
+// `minecraft:oak_log[axis=x]`. Properties are sorted so the output is stable.
+func BlockWithState(material string, state map[string]string) string {
+	if len(state) == 0 {
+		return material
+	}
+
+	pairs := make([]string, 0, len(state))
+	for _, key := range sortedKeys(state) {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, state[key]))
+	}
+	return fmt.Sprintf("%s[%s]", material, strings.Join(pairs, ","))
+}
+
+// ParseBlock splits a block such as `minecraft:oak_log[axis=x]` into its ID
+// and state. It is the inverse of BlockWithState.
+func ParseBlock(block string) (string, map[string]string) {
+	i := strings.Index(block, "[")
+	if i < 0 || !strings.HasSuffix(block, "]") {
+		return block, nil
+	}
+
+	state := map[string]string{}
+	for _, pair := range strings.Split(block[i+1:len(block)-1], ",") {
+		if kv := strings.SplitN(pair, "=", 2); len(kv) == 2 {
+			state[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+	}
+	return block[:i], state
+}
+
+// TestBlock reports whether the block at the given position matches block,
+// which may carry a partial state such as `minecraft:furnace[lit=true]`.
+func (c Client) TestBlock(ctx context.Context, block string, x, y, z int) (bool, error) {
+	out, err := c.send(fmt.Sprintf("execute if block %d %d %d %s", x, y, z, block))
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case strings.Contains(out, "Test passed"):
+		return true, nil
+	case strings.Contains(out, "Test failed"):
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected response: %q", out)
+	}
+}
+
+// IsAir reports whether the block at the given position is any kind of air.
+func (c Client) IsAir(ctx context.Context, x, y, z int) (bool, error) {
+	for _, air := range []string{"minecraft:air", "minecraft:cave_air", "minecraft:void_air"} {
+		ok, err := c.TestBlock(ctx, air, x, y, z)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// GetBlockState probes the block at the given position for the current value
+// of each property in candidates. Properties whose value cannot be matched
+// against any candidate are left out of the result.
+func (c Client) GetBlockState(ctx context.Context, material string, candidates map[string][]string, x, y, z int) (map[string]string, error) {
+	state := map[string]string{}
+	for _, key := range sortedPropertyKeys(candidates) {
+		for _, value := range candidates[key] {
+			ok, err := c.TestBlock(ctx, BlockWithState(material, map[string]string{key: value}), x, y, z)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				state[key] = value
+				break
+			}
+		}
+	}
+	return state, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedPropertyKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package minecraft
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// Item stacks moved from a free-form `tag` compound to typed data components
+// in 1.20.5, and container locks became item predicates in 1.21.2.
+var (
+	itemComponentsVersion = MustParseVersion("1.20.5")
+	lockPredicateVersion  = MustParseVersion("1.21.2")
+)
+
+// ChestSlots is the number of slots in a single chest.
+const ChestSlots = 27
+
+// ContainerItem is an item stack in a container slot.
+type ContainerItem struct {
+	Slot         int
+	ID           string
+	Count        int
+	Enchantments map[string]int
+	CustomName   string
+	Lore         []string
+}
+
+// Container is the block entity data of a chest-like container. Nil Items
+// leaves the inventory untouched; an empty slice clears it.
+type Container struct {
+	CustomName string
+	Lock       string
+	LootTable  string
+	Items      []ContainerItem
+}
+
+// ContainerNBT renders the container as block entity SNBT for the given
+// server version.
+func ContainerNBT(version Version, c Container) string {
+	var tags []string
+	if c.CustomName != "" {
+		tags = append(tags, fmt.Sprintf("CustomName:%s", QuoteSNBT(textComponent(c.CustomName))))
+	}
+	if c.Lock != "" {
+		if version.AtLeast(lockPredicateVersion) {
+			tags = append(tags, fmt.Sprintf(`lock:{components:{"minecraft:custom_name":%s}}`, QuoteSNBT(jsonString(c.Lock))))
+		} else {
+			tags = append(tags, fmt.Sprintf("Lock:%q", c.Lock))
+		}
+	}
+	if c.LootTable != "" {
+		tags = append(tags, fmt.Sprintf("LootTable:%q", c.LootTable))
+	}
+	if c.Items != nil {
+		items := make([]string, 0, len(c.Items))
+		for _, item := range c.Items {
+			items = append(items, itemNBT(version, item))
+		}
+		tags = append(tags, fmt.Sprintf("Items:[%s]", strings.Join(items, ",")))
+	}
+	return "{" + strings.Join(tags, ",") + "}"
+}
+
+func itemNBT(version Version, item ContainerItem) string {
+	count := item.Count
+	if count == 0 {
+		count = 1
+	}
+	id := NormalizeMaterial(item.ID)
+
+	var lore []string
+	for _, line := range item.Lore {
+		lore = append(lore, QuoteSNBT(textComponent(line)))
+	}
+	enchantments := sortedEnchantments(item.Enchantments)
+
+	if version.AtLeast(itemComponentsVersion) {
+		var components []string
+		if item.CustomName != "" {
+			components = append(components, fmt.Sprintf(`"minecraft:custom_name":%s`, QuoteSNBT(textComponent(item.CustomName))))
+		}
+		if len(lore) > 0 {
+			components = append(components, fmt.Sprintf(`"minecraft:lore":[%s]`, strings.Join(lore, ",")))
+		}
+		if len(enchantments) > 0 {
+			levels := make([]string, 0, len(enchantments))
+			for _, e := range enchantments {
+				levels = append(levels, fmt.Sprintf("%q:%d", NormalizeMaterial(e), item.Enchantments[e]))
+			}
+			components = append(components, fmt.Sprintf(`"minecraft:enchantments":{levels:{%s}}`, strings.Join(levels, ",")))
+		}
+
+		nbt := fmt.Sprintf("{Slot:%db,id:%q,count:%d", item.Slot, id, count)
+		if len(components) > 0 {
+			nbt += fmt.Sprintf(",components:{%s}", strings.Join(components, ","))
+		}
+		return nbt + "}"
+	}
+
+	var tag []string
+	var display []string
+	if item.CustomName != "" {
+		display = append(display, fmt.Sprintf("Name:%s", QuoteSNBT(textComponent(item.CustomName))))
+	}
+	if len(lore) > 0 {
+		display = append(display, fmt.Sprintf("Lore:[%s]", strings.Join(lore, ",")))
+	}
+	if len(display) > 0 {
+		tag = append(tag, fmt.Sprintf("display:{%s}", strings.Join(display, ",")))
+	}
+	if len(enchantments) > 0 {
+		list := make([]string, 0, len(enchantments))
+		for _, e := range enchantments {
+			list = append(list, fmt.Sprintf("{id:%q,lvl:%ds}", NormalizeMaterial(e), item.Enchantments[e]))
+		}
+		tag = append(tag, fmt.Sprintf("Enchantments:[%s]", strings.Join(list, ",")))
+	}
+
+	nbt := fmt.Sprintf("{Slot:%db,id:%q,Count:%db", item.Slot, id, count)
+	if len(tag) > 0 {
+		nbt += fmt.Sprintf(",tag:{%s}", strings.Join(tag, ","))
+	}
+	return nbt + "}"
+}
+
+// ParseContainerNBT reads container data back from block entity SNBT as
+// returned by GetBlockData. Items are sorted by slot.
+func ParseContainerNBT(snbt string) (Container, error) {
+	tag, err := nbt.ParseCompound(snbt)
+	if err != nil {
+		return Container{}, err
+	}
+
+	var c Container
+	if name, ok := tag["CustomName"].(string); ok {
+		c.CustomName = plainText(name)
+	}
+	if lock, ok := tag["Lock"].(string); ok {
+		c.Lock = lock
+	}
+	if lock, ok := tag["lock"].(nbt.Compound); ok {
+		components, _ := lock["components"].(nbt.Compound)
+		if name, ok := components["minecraft:custom_name"].(string); ok {
+			c.Lock = plainText(name)
+		}
+	}
+	c.LootTable, _ = tag["LootTable"].(string)
+
+	items, _ := tag["Items"].(nbt.List)
+	c.Items = make([]ContainerItem, 0, len(items.Items))
+	for _, raw := range items.Items {
+		entry, ok := raw.(nbt.Compound)
+		if !ok {
+			continue
+		}
+		c.Items = append(c.Items, parseItem(entry))
+	}
+	sort.Slice(c.Items, func(i, j int) bool { return c.Items[i].Slot < c.Items[j].Slot })
+	return c, nil
+}
+
+func parseItem(entry nbt.Compound) ContainerItem {
+	item := ContainerItem{
+		Slot:  int(nbtInt(entry["Slot"])),
+		Count: 1,
+	}
+	item.ID, _ = entry["id"].(string)
+	if n, ok := entry["Count"]; ok {
+		item.Count = int(nbtInt(n))
+	}
+	if n, ok := entry["count"]; ok {
+		item.Count = int(nbtInt(n))
+	}
+
+	// 1.20.5+ data components.
+	if components, ok := entry["components"].(nbt.Compound); ok {
+		if name, ok := components["minecraft:custom_name"].(string); ok {
+			item.CustomName = plainText(name)
+		}
+		item.Lore = parseLore(components["minecraft:lore"])
+		if enchantments, ok := components["minecraft:enchantments"].(nbt.Compound); ok {
+			levels, ok := enchantments["levels"].(nbt.Compound)
+			if !ok {
+				levels = enchantments
+			}
+			for id, lvl := range levels {
+				if item.Enchantments == nil {
+					item.Enchantments = map[string]int{}
+				}
+				item.Enchantments[id] = int(nbtInt(lvl))
+			}
+		}
+		return item
+	}
+
+	// Legacy `tag` compound.
+	tag, _ := entry["tag"].(nbt.Compound)
+	if display, ok := tag["display"].(nbt.Compound); ok {
+		if name, ok := display["Name"].(string); ok {
+			item.CustomName = plainText(name)
+		}
+		item.Lore = parseLore(display["Lore"])
+	}
+	enchantments, _ := tag["Enchantments"].(nbt.List)
+	for _, raw := range enchantments.Items {
+		e, _ := raw.(nbt.Compound)
+		id, _ := e["id"].(string)
+		if id == "" {
+			continue
+		}
+		if item.Enchantments == nil {
+			item.Enchantments = map[string]int{}
+		}
+		item.Enchantments[id] = int(nbtInt(e["lvl"]))
+	}
+	return item
+}
+
+func parseLore(v interface{}) []string {
+	list, _ := v.(nbt.List)
+	var lore []string
+	for _, raw := range list.Items {
+		line, _ := raw.(string)
+		lore = append(lore, plainText(line))
+	}
+	return lore
+}
+
+func sortedEnchantments(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -11,6 +11,9 @@ import (
 
 type Client struct {
 	client *rcon.Client
+
+	// dimension, when set, is the dimension world-editing commands run in.
+	dimension string
 }
 
 type Player struct {
@@ -29,7 +32,24 @@ func New(address string, password string) (*Client, error) {
 		return nil, err
 	}
 
-	return &Client{client}, nil
+	return &Client{client: client}, nil
+}
+
+// InDimension returns a copy of the client whose block and entity commands
+// run in the given dimension (e.g. "minecraft:the_nether") by wrapping them
+// in `execute in <dimension> run …`. An empty dimension keeps the sender's
+// default context, which is the overworld for RCON.
+func (c Client) InDimension(dimension string) Client {
+	c.dimension = dimension
+	return c
+}
+
+// send runs a world-editing command in the client's dimension.
+func (c Client) send(command string) (string, error) {
+	if c.dimension != "" {
+		command = fmt.Sprintf("execute in %s run %s", c.dimension, command)
+	}
+	return c.client.SendCommand(command)
 }
 
 // Get a player.
@@ -40,23 +60,32 @@ func (c Client) GetPlayer(ctx context.Context, name string) error {
 // Creates a block.
 func (c Client) CreateBlock(ctx context.Context, material string, x, y, z int) error {
 	command := fmt.Sprintf("setblock %d %d %d %s replace", x, y, z, material)
-	_, err := c.client.SendCommand(command)
+	out, err := c.send(command)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return checkSetBlock(out, x, y, z)
 }
 
 // Deletes a block.
 func (c Client) DeleteBlock(ctx context.Context, x, y, z int) error {
 	command := fmt.Sprintf("setblock %d %d %d minecraft:air replace", x, y, z)
-	_, err := c.client.SendCommand(command)
+	out, err := c.send(command)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return checkSetBlock(out, x, y, z)
+}
+
+// checkSetBlock turns a rejected `setblock` into an error. "Could not set
+// the block" means the block was already in that state, which is success.
+func checkSetBlock(out string, x, y, z int) error {
+	if strings.Contains(out, "Changed the block") || strings.Contains(out, "Could not set the block") {
+		return nil
+	}
+	return fmt.Errorf("setblock %d %d %d: %s", x, y, z, strings.TrimSpace(out))
 }
 
 // CreateStairs places a stairs block (e.g., "minecraft:oak_stairs") with orientation.
@@ -65,14 +94,14 @@ func (c Client) CreateStairs(ctx context.Context, material string, x, y, z int,
 		`setblock %d %d %d %s[facing=%s,half=%s,shape=%s,waterlogged=%t] replace`,
 		x, y, z, material, facing, half, shape, waterlogged,
 	)
-	_, err := c.client.SendCommand(cmd)
+	_, err := c.send(cmd)
 	return err
 }
 
 // Creates an entity.
 func (c Client) CreateEntity(ctx context.Context, entity string, position string, id string) error {
 	command := fmt.Sprintf("summon %s %s {CustomName:'{\"text\":\"%s\"}'}", entity, position, id)
-	_, err := c.client.SendCommand(command)
+	_, err := c.send(command)
 	if err != nil {
 		return err
 	}
@@ -122,7 +151,7 @@ func (c Client) CreateZombie(
 		health,
 	)
 
-	_, err := c.client.SendCommand(command)
+	_, err := c.send(command)
 	if err != nil {
 		return err
 	}
@@ -170,7 +199,7 @@ func (c Client) CreateSheep(ctx context.Context, position string, id string, col
 		position, id, colorVal, shearedVal,
 	)
 
-	_, err := c.client.SendCommand(command)
+	_, err := c.send(command)
 	if err != nil {
 		return err
 	}
@@ -182,14 +211,14 @@ func (c Client) CreateSheep(ctx context.Context, position string, id string, col
 func (c Client) DeleteEntity(ctx context.Context, entity string, position string, id string) error {
 	// Remove the entity.
 	command := fmt.Sprintf("kill @e[type=%s,nbt={CustomName:'{\"text\":\"%s\"}'}]", entity, id)
-	_, err := c.client.SendCommand(command)
+	_, err := c.send(command)
 	if err != nil {
 		return err
 	}
 
 	// Remove the entity from inventories.
 	command = fmt.Sprintf("clear @a %s{display:{Name:'{\"text\":\"%s\"}'}}", entity, id)
-	_, err = c.client.SendCommand(command)
+	_, err = c.send(command)
 	if err != nil {
 		return err
 	}
@@ -197,6 +226,19 @@ func (c Client) DeleteEntity(ctx context.Context, entity string, position string
 	return nil
 }
 
+// SummonEntity summons an entity at an exact position with SNBT data.
+func (c Client) SummonEntity(ctx context.Context, entity string, x, y, z float64, nbt string) error {
+	_, err := c.send(fmt.Sprintf("summon %s %s %s %s %s", entity,
+		strconv.FormatFloat(x, 'f', -1, 64), strconv.FormatFloat(y, 'f', -1, 64), strconv.FormatFloat(z, 'f', -1, 64), nbt))
+	return err
+}
+
+// KillEntitiesByTag removes every entity carrying tag.
+func (c Client) KillEntitiesByTag(ctx context.Context, tag string) error {
+	_, err := c.send(fmt.Sprintf("kill @e[tag=%s]", tag))
+	return err
+}
+
 
 // GameMode names keyed by the numeric values returned by Minecraft.
 var gameModeNames = map[int]string{
@@ -635,10 +677,23 @@ func isIntRule(rule string) bool {
 
 func (c Client) FillBlock(ctx context.Context, material string, sx, sy, sz, ex, ey, ez int) error {
 	command := fmt.Sprintf("fill %d %d %d %d %d %d %s hollow", sx, sy, sz, ex, ey, ez, material)
-	_, err := c.client.SendCommand(command)
+	_, err := c.send(command)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// SaveAll makes the server write every loaded chunk to disk, so the world
+// files match what is in game.
+func (c Client) SaveAll(ctx context.Context) error {
+	out, err := c.client.SendCommand("save-all flush")
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(out, "Saved the game") && !strings.Contains(out, "Saving") {
+		return fmt.Errorf("unexpected response: %q", out)
+	}
+	return nil
+}
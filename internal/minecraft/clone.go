@@ -0,0 +1,148 @@
+package minecraft
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// `clone from <dimension> … to <dimension> …` arrived in 1.20.2.
+var cloneDimensionsVersion = MustParseVersion("1.20.2")
+
+// CloneSupportsDimensions reports whether the server can clone between
+// dimensions.
+func CloneSupportsDimensions(version Version) bool {
+	return version.AtLeast(cloneDimensionsVersion)
+}
+
+// CloneMaskModes and CloneModes are the values `clone` accepts.
+var (
+	CloneMaskModes = []string{"replace", "masked", "filtered"}
+	CloneModes     = []string{"normal", "force", "move"}
+)
+
+// Clone copies the blocks in Source, along with their block entities, so
+// that Source.Min lands on Destination.
+type Clone struct {
+	Source               Box
+	SourceDimension      string
+	Destination          Pos
+	DestinationDimension string
+
+	// Mask is replace, masked (skip air) or filtered (only blocks matching
+	// Filter, a block predicate such as `#minecraft:logs`).
+	Mask   string
+	Filter string
+
+	// Mode is normal, force (allow overlapping areas) or move (clear the
+	// source afterwards).
+	Mode string
+}
+
+// Validate checks the modes and that a filter is set exactly when needed.
+func (c Clone) Validate() error {
+	if c.Mask == "" || !containsString(CloneMaskModes, c.Mask) {
+		return fmt.Errorf("mask mode must be one of: %s (got %q)", strings.Join(CloneMaskModes, ", "), c.Mask)
+	}
+	if c.Mode == "" || !containsString(CloneModes, c.Mode) {
+		return fmt.Errorf("clone mode must be one of: %s (got %q)", strings.Join(CloneModes, ", "), c.Mode)
+	}
+	if (c.Mask == "filtered") != (c.Filter != "") {
+		return fmt.Errorf("a filter must be set with, and only with, the filtered mask mode")
+	}
+	if c.Mode == "normal" && c.SourceDimension == c.DestinationDimension && overlaps(c.Source, c.DestinationBox()) {
+		return fmt.Errorf("the source and destination areas overlap; use the force or move clone mode")
+	}
+	return nil
+}
+
+func overlaps(a, b Box) bool {
+	return a.Min.X <= b.Max.X && b.Min.X <= a.Max.X &&
+		a.Min.Y <= b.Max.Y && b.Min.Y <= a.Max.Y &&
+		a.Min.Z <= b.Max.Z && b.Min.Z <= a.Max.Z
+}
+
+// DestinationBox returns the area the clone writes to.
+func (c Clone) DestinationBox() Box {
+	offset := c.offset()
+	return Box{Min: c.Source.Min.add(offset), Max: c.Source.Max.add(offset)}
+}
+
+func (c Clone) offset() Pos {
+	return Pos{c.Destination.X - c.Source.Min.X, c.Destination.Y - c.Source.Min.Y, c.Destination.Z - c.Source.Min.Z}
+}
+
+func (p Pos) add(o Pos) Pos {
+	return Pos{p.X + o.X, p.Y + o.Y, p.Z + o.Z}
+}
+
+// Clone runs the clone, split into `clone` commands of at most
+// MaxFillVolume blocks. Pieces furthest along the direction of travel go
+// first so a forced clone onto an overlapping area never reads blocks it
+// has already overwritten. It returns the number of commands sent.
+func (c Client) Clone(ctx context.Context, version Version, clone Clone) (int, error) {
+	if err := clone.Validate(); err != nil {
+		return 0, err
+	}
+	crossDimension := clone.SourceDimension != "" || clone.DestinationDimension != ""
+	if crossDimension && !CloneSupportsDimensions(version) {
+		if clone.SourceDimension != clone.DestinationDimension {
+			return 0, fmt.Errorf("cloning between dimensions needs Minecraft %s or later", cloneDimensionsVersion)
+		}
+		// Both ends are in the same dimension, so run the whole command there.
+		c = c.InDimension(clone.SourceDimension)
+		clone.SourceDimension, clone.DestinationDimension = "", ""
+	}
+
+	offset := clone.offset()
+	pieces := SplitBox(clone.Source)
+	sort.SliceStable(pieces, func(i, j int) bool {
+		return pieces[i].Min.dot(offset) > pieces[j].Min.dot(offset)
+	})
+
+	for i, piece := range pieces {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+		out, err := c.send(clone.command(piece, piece.Min.add(offset)))
+		if err != nil {
+			return i, err
+		}
+		// "No blocks cloned" is not a failure: a masked clone of air does
+		// nothing.
+		if !strings.Contains(out, "cloned") {
+			return i, fmt.Errorf("clone %s to %s: %s", piece.Min, piece.Min.add(offset), strings.TrimSpace(out))
+		}
+	}
+	return len(pieces), nil
+}
+
+func (p Pos) dot(o Pos) int {
+	return p.X*o.X + p.Y*o.Y + p.Z*o.Z
+}
+
+func (clone Clone) command(source Box, destination Pos) string {
+	var b strings.Builder
+	b.WriteString("clone ")
+	if clone.SourceDimension != "" || clone.DestinationDimension != "" {
+		fmt.Fprintf(&b, "from %s ", dimensionOrOverworld(clone.SourceDimension))
+	}
+	fmt.Fprintf(&b, "%d %d %d %d %d %d ", source.Min.X, source.Min.Y, source.Min.Z, source.Max.X, source.Max.Y, source.Max.Z)
+	if clone.SourceDimension != "" || clone.DestinationDimension != "" {
+		fmt.Fprintf(&b, "to %s ", dimensionOrOverworld(clone.DestinationDimension))
+	}
+	fmt.Fprintf(&b, "%d %d %d %s", destination.X, destination.Y, destination.Z, clone.Mask)
+	if clone.Mask == "filtered" {
+		fmt.Fprintf(&b, " %s", clone.Filter)
+	}
+	fmt.Fprintf(&b, " %s", clone.Mode)
+	return b.String()
+}
+
+func dimensionOrOverworld(dimension string) string {
+	if dimension == "" {
+		return "minecraft:overworld"
+	}
+	return dimension
+}
@@ -0,0 +1,27 @@
+package minecraft
+
+// DyeColors are the 16 dye colours used by wool, beds, banners, sign text
+// and sheep, in their NBT ID order.
+var DyeColors = []string{
+	"white",
+	"orange",
+	"magenta",
+	"light_blue",
+	"yellow",
+	"lime",
+	"pink",
+	"gray",
+	"light_gray",
+	"cyan",
+	"purple",
+	"blue",
+	"brown",
+	"green",
+	"red",
+	"black",
+}
+
+// IsDyeColor reports whether color is one of DyeColors.
+func IsDyeColor(color string) bool {
+	return containsString(DyeColors, color)
+}
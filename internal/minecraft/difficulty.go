@@ -0,0 +1,63 @@
+package minecraft
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// Difficulties are the values `difficulty` accepts, easiest first.
+var Difficulties = []string{"peaceful", "easy", "normal", "hard"}
+
+// ValidateDifficulty checks that difficulty is one of Difficulties.
+func ValidateDifficulty(difficulty string) error {
+	for _, d := range Difficulties {
+		if difficulty == d {
+			return nil
+		}
+	}
+	return fmt.Errorf("difficulty must be one of %s (got %q)", strings.Join(Difficulties, ", "), difficulty)
+}
+
+// HardcoreFromLevel reports whether level.dat's Data compound is of a
+// hardcore world, whose difficulty is fixed at hard.
+func HardcoreFromLevel(level nbt.Compound) bool {
+	hardcore, _ := level["hardcore"].(int8)
+	return hardcore != 0
+}
+
+// SetDifficulty changes the difficulty.
+func (c Client) SetDifficulty(ctx context.Context, difficulty string) error {
+	if err := ValidateDifficulty(difficulty); err != nil {
+		return err
+	}
+	out, err := c.send("difficulty " + difficulty)
+	if err != nil {
+		return err
+	}
+	// "The difficulty has been set to Hard", or "The difficulty did not
+	// change; it is already set to hard"
+	if !strings.Contains(out, "set to") {
+		return fmt.Errorf("difficulty %s: %s", difficulty, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// Typical output: The difficulty is Normal
+var difficultyPattern = regexp.MustCompile(`difficulty is (\w+)`)
+
+// GetDifficulty returns the current difficulty in lowercase, e.g. "normal".
+func (c Client) GetDifficulty(ctx context.Context) (string, error) {
+	out, err := c.send("difficulty")
+	if err != nil {
+		return "", err
+	}
+	m := difficultyPattern.FindStringSubmatch(out)
+	if m == nil {
+		return "", fmt.Errorf("unexpected response: %q", strings.TrimSpace(out))
+	}
+	return strings.ToLower(m[1]), nil
+}
@@ -0,0 +1,41 @@
+package minecraft
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Vanilla dimension IDs. Datapacks may add more under their own namespace.
+var knownDimensions = map[string]struct{}{
+	"minecraft:overworld":  {},
+	"minecraft:the_nether": {},
+	"minecraft:the_end":    {},
+}
+
+// Dimension IDs are `namespace:path` with lowercase characters only.
+var dimensionPattern = regexp.MustCompile(`^[a-z0-9_.-]+:[a-z0-9_./-]+$`)
+
+// NormalizeDimension adds the implicit `minecraft:` namespace to bare IDs.
+func NormalizeDimension(dimension string) string {
+	dimension = strings.ToLower(strings.TrimSpace(dimension))
+	if dimension != "" && !strings.Contains(dimension, ":") {
+		dimension = "minecraft:" + dimension
+	}
+	return dimension
+}
+
+// ValidateDimension accepts the vanilla dimensions and any resource location
+// outside the `minecraft` namespace.
+func ValidateDimension(dimension string) error {
+	d := NormalizeDimension(dimension)
+	if !dimensionPattern.MatchString(d) {
+		return fmt.Errorf("dimension must be a resource location such as `minecraft:the_nether` (got %q)", dimension)
+	}
+	if strings.HasPrefix(d, "minecraft:") {
+		if _, ok := knownDimensions[d]; !ok {
+			return fmt.Errorf("unknown vanilla dimension %q; expected one of minecraft:overworld, minecraft:the_nether, minecraft:the_end", dimension)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package minecraft
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PaletteEntry is a block and the average colour of its texture.
+type PaletteEntry struct {
+	Block string
+	Color color.RGBA
+}
+
+// Palette is a set of blocks to draw with.
+type Palette []PaletteEntry
+
+// Average texture colours of the dyed block families, in DyeColors order.
+var (
+	woolColors = []string{
+		"#e9ecec", "#f07613", "#bd44b3", "#3aafd9", "#f8c527", "#70b919", "#ed8dac", "#3e4447",
+		"#8e8e86", "#158991", "#792aac", "#35399d", "#724728", "#546d1b", "#a12722", "#141519",
+	}
+	concreteColors = []string{
+		"#cfd5d6", "#e06100", "#a9309f", "#2389c6", "#f0af15", "#5ea818", "#d5658e", "#36393d",
+		"#7d7d73", "#157788", "#64209c", "#2c2e8f", "#603b1f", "#495b24", "#8e2020", "#080a0f",
+	}
+	terracottaColors = []string{
+		"#d1b2a1", "#a15325", "#95576c", "#706c8a", "#ba8523", "#677534", "#a14e4e", "#392a23",
+		"#876a61", "#565b5b", "#764656", "#4a3b5b", "#4d3323", "#4c532a", "#8f3d2e", "#251610",
+	}
+)
+
+// PaletteNames are the built-in palettes.
+var PaletteNames = []string{"wool", "concrete", "terracotta"}
+
+// BuiltinPalette returns one of the palettes in PaletteNames.
+func BuiltinPalette(name string) (Palette, error) {
+	var colors []string
+	var suffix string
+	switch name {
+	case "wool":
+		colors, suffix = woolColors, "_wool"
+	case "concrete":
+		colors, suffix = concreteColors, "_concrete"
+	case "terracotta":
+		colors, suffix = terracottaColors, "_terracotta"
+	default:
+		return nil, fmt.Errorf("palette must be one of: %s (got %q)", strings.Join(PaletteNames, ", "), name)
+	}
+
+	palette := make(Palette, 0, len(colors)+1)
+	for i, hex := range colors {
+		c, _ := ParseHexColor(hex)
+		palette = append(palette, PaletteEntry{Block: "minecraft:" + DyeColors[i] + suffix, Color: c})
+	}
+	if name == "terracotta" {
+		palette = append(palette, PaletteEntry{Block: "minecraft:terracotta", Color: color.RGBA{0x98, 0x5e, 0x43, 0xff}})
+	}
+	return palette, nil
+}
+
+// CustomPalette builds a palette from block => "#rrggbb" pairs.
+func CustomPalette(colors map[string]string) (Palette, error) {
+	if len(colors) == 0 {
+		return nil, fmt.Errorf("a custom palette needs at least one block")
+	}
+
+	blocks := make([]string, 0, len(colors))
+	for block := range colors {
+		blocks = append(blocks, block)
+	}
+	sort.Strings(blocks)
+
+	palette := make(Palette, 0, len(colors))
+	for _, block := range blocks {
+		c, err := ParseHexColor(colors[block])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", block, err)
+		}
+		palette = append(palette, PaletteEntry{Block: block, Color: c})
+	}
+	return palette, nil
+}
+
+// ParseHexColor parses a colour written as "#rrggbb".
+func ParseHexColor(s string) (color.RGBA, error) {
+	hex := strings.TrimPrefix(s, "#")
+	if len(hex) != 6 {
+		return color.RGBA{}, fmt.Errorf("colour %q must be written as #rrggbb", s)
+	}
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("colour %q must be written as #rrggbb", s)
+	}
+	return color.RGBA{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n), A: 0xff}, nil
+}
+
+// Nearest returns the palette entry closest to the colour (r, g, b), using
+// the "redmean" weighted distance, which tracks perceived difference better
+// than plain RGB distance.
+func (p Palette) Nearest(r, g, b float64) PaletteEntry {
+	best, bestDist := p[0], -1.0
+	for _, e := range p {
+		er, eg, eb := float64(e.Color.R), float64(e.Color.G), float64(e.Color.B)
+		mean := (r + er) / 2
+		dr, dg, db := r-er, g-eg, b-eb
+		dist := (2+mean/256)*dr*dr + 4*dg*dg + (2+(255-mean)/256)*db*db
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = e, dist
+		}
+	}
+	return best
+}
@@ -0,0 +1,159 @@
+package minecraft
+
+import (
+	"fmt"
+	"image"
+	_ "image/jpeg" // register the JPEG decoder
+	_ "image/png"  // register the PNG decoder
+	"io"
+	"os"
+)
+
+// Pixel is a colour with straight (non-premultiplied) 0-255 channels.
+type Pixel struct {
+	R, G, B, A float64
+}
+
+// MaxImagePixels bounds the images LoadImage decodes, which are held in
+// memory whole.
+const MaxImagePixels = 4096 * 4096
+
+// LoadImage decodes a PNG or JPEG file no larger than MaxImagePixels.
+func LoadImage(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// Check the size from the header before decoding the pixels.
+	config, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, fmt.Errorf("%s is %dx%d pixels; images may have at most %d", path, config.Width, config.Height, MaxImagePixels)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return img, nil
+}
+
+// ScaledSize resolves a target size for img. A zero width or height is worked
+// out from the other to keep the aspect ratio; both zero keeps the image size.
+func ScaledSize(img image.Image, width, height int) (int, int) {
+	b := img.Bounds()
+	switch {
+	case width == 0 && height == 0:
+		return b.Dx(), b.Dy()
+	case width == 0:
+		width = maxInt(1, (b.Dx()*height+b.Dy()/2)/b.Dy())
+	case height == 0:
+		height = maxInt(1, (b.Dy()*width+b.Dx()/2)/b.Dx())
+	}
+	return width, height
+}
+
+// Resample scales img to width x height by averaging the source pixels that
+// fall in each target pixel, which keeps detail when shrinking a large image.
+// The result is indexed [y][x].
+func Resample(img image.Image, width, height int) [][]Pixel {
+	b := img.Bounds()
+	out := make([][]Pixel, height)
+	for y := 0; y < height; y++ {
+		out[y] = make([]Pixel, width)
+		sy0 := b.Min.Y + y*b.Dy()/height
+		sy1 := maxInt(sy0+1, b.Min.Y+(y+1)*b.Dy()/height)
+		for x := 0; x < width; x++ {
+			sx0 := b.Min.X + x*b.Dx()/width
+			sx1 := maxInt(sx0+1, b.Min.X+(x+1)*b.Dx()/width)
+
+			var sum Pixel
+			var n float64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					r, g, bl, a := img.At(sx, sy).RGBA()
+					// RGBA is alpha-premultiplied, so dividing by the alpha
+					// sum below keeps transparent pixels from darkening
+					// the average.
+					sum.R += float64(r) / 257
+					sum.G += float64(g) / 257
+					sum.B += float64(bl) / 257
+					sum.A += float64(a) / 257
+					n++
+				}
+			}
+			p := Pixel{A: sum.A / n}
+			if sum.A > 0 {
+				p.R, p.G, p.B = sum.R*255/sum.A, sum.G*255/sum.A, sum.B*255/sum.A
+			}
+			out[y][x] = p
+		}
+	}
+	return out
+}
+
+// Quantize maps every pixel to the nearest palette block, optionally with
+// Floyd–Steinberg dithering. Pixels less than half opaque become "", meaning
+// no block. The result is indexed [y][x].
+func Quantize(pixels [][]Pixel, palette Palette, dither bool) [][]string {
+	height := len(pixels)
+	out := make([][]string, height)
+	if height == 0 {
+		return out
+	}
+	width := len(pixels[0])
+
+	// Work on a copy: dithering pushes error into pixels not yet visited.
+	work := make([][]Pixel, height)
+	for y := range pixels {
+		work[y] = append([]Pixel(nil), pixels[y]...)
+	}
+
+	spread := func(x, y int, er, eg, eb, weight float64) {
+		if x < 0 || x >= width || y >= height || work[y][x].A < 128 {
+			return
+		}
+		work[y][x].R += er * weight
+		work[y][x].G += eg * weight
+		work[y][x].B += eb * weight
+	}
+
+	for y := 0; y < height; y++ {
+		out[y] = make([]string, width)
+		for x := 0; x < width; x++ {
+			p := work[y][x]
+			if p.A < 128 {
+				continue
+			}
+			r, g, b := clamp255(p.R), clamp255(p.G), clamp255(p.B)
+			e := palette.Nearest(r, g, b)
+			out[y][x] = e.Block
+
+			if dither {
+				er, eg, eb := r-float64(e.Color.R), g-float64(e.Color.G), b-float64(e.Color.B)
+				spread(x+1, y, er, eg, eb, 7.0/16)
+				spread(x-1, y+1, er, eg, eb, 3.0/16)
+				spread(x, y+1, er, eg, eb, 5.0/16)
+				spread(x+1, y+1, er, eg, eb, 1.0/16)
+			}
+		}
+	}
+	return out
+}
+
+func clamp255(v float64) float64 {
+	switch {
+	case v < 0:
+		return 0
+	case v > 255:
+		return 255
+	}
+	return v
+}
@@ -0,0 +1,72 @@
+package minecraft
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PlaceKinds are the world generation pieces `place` can generate on demand.
+var PlaceKinds = []string{"feature", "jigsaw", "structure"}
+
+// MaxJigsawDepth is the deepest jigsaw `place jigsaw` accepts.
+const MaxJigsawDepth = 20
+
+// Placement is one run of world generation at Position: a configured
+// feature such as `minecraft:oak` or `minecraft:ore_diamond`, a jigsaw grown
+// from a template pool, or a whole structure such as `minecraft:village_plains`.
+type Placement struct {
+	Kind     string
+	Name     string // feature, start pool or structure
+	Position Pos
+
+	// Target is the name of the jigsaw block in the start pool to grow
+	// from, and MaxDepth how many jigsaw steps to take. Jigsaws only.
+	Target   string
+	MaxDepth int
+}
+
+// Validate checks the kind, the names and the jigsaw settings.
+func (p Placement) Validate() error {
+	if !containsString(PlaceKinds, p.Kind) {
+		return fmt.Errorf("type must be one of: %s (got %q)", strings.Join(PlaceKinds, ", "), p.Kind)
+	}
+	if !resourceLocationPattern.MatchString(p.Name) {
+		return fmt.Errorf("%s must be a resource location such as `minecraft:oak` (got %q)", p.Kind, p.Name)
+	}
+	if p.Kind != "jigsaw" {
+		return nil
+	}
+	if !resourceLocationPattern.MatchString(p.Target) {
+		return fmt.Errorf("jigsaw target must be a resource location such as `minecraft:bottom` (got %q)", p.Target)
+	}
+	if p.MaxDepth < 1 || p.MaxDepth > MaxJigsawDepth {
+		return fmt.Errorf("jigsaw depth must be between 1 and %d (got %d)", MaxJigsawDepth, p.MaxDepth)
+	}
+	return nil
+}
+
+// Place runs the placement. Generation is random, and can fail where the
+// terrain doesn't suit the feature or structure.
+func (c Client) Place(ctx context.Context, version Version, p Placement) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if !PlaceSupported(version) {
+		return fmt.Errorf("placing world generation needs Minecraft %s or later", placeVersion)
+	}
+
+	command := fmt.Sprintf("place %s %s %d %d %d", p.Kind, p.Name, p.Position.X, p.Position.Y, p.Position.Z)
+	if p.Kind == "jigsaw" {
+		command = fmt.Sprintf("place jigsaw %s %s %d %d %d %d", p.Name, p.Target, p.MaxDepth, p.Position.X, p.Position.Y, p.Position.Z)
+	}
+	out, err := c.send(command)
+	if err != nil {
+		return err
+	}
+	// "Placed …" for features, "Generated …" for jigsaws and structures.
+	if !strings.Contains(out, "Placed") && !strings.Contains(out, "Generated") {
+		return fmt.Errorf("place %s %s: %s", p.Kind, p.Name, strings.TrimSpace(out))
+	}
+	return nil
+}
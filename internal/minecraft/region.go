@@ -0,0 +1,228 @@
+package minecraft
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxFillVolume is the most blocks a single `fill` may change (the default
+// `commandModificationBlockLimit`).
+const MaxFillVolume = 32768
+
+// Pos is a block position.
+type Pos struct {
+	X, Y, Z int
+}
+
+// ParsePos parses a position written as "x,y,z".
+func ParsePos(s string) (Pos, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return Pos{}, fmt.Errorf("position %q must be written as x,y,z", s)
+	}
+
+	var coords [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return Pos{}, fmt.Errorf("position %q must be written as x,y,z with whole numbers", s)
+		}
+		coords[i] = n
+	}
+	return Pos{X: coords[0], Y: coords[1], Z: coords[2]}, nil
+}
+
+// String renders the position as "x,y,z".
+func (p Pos) String() string {
+	return fmt.Sprintf("%d,%d,%d", p.X, p.Y, p.Z)
+}
+
+// Box is an inclusive cuboid of one block.
+type Box struct {
+	Min, Max Pos
+	Block    string
+}
+
+// ParseBox parses a region written as "x1,y1,z1:x2,y2,z2". The corners may
+// be given in any order.
+func ParseBox(s string) (Box, error) {
+	corners := strings.Split(s, ":")
+	if len(corners) != 2 {
+		return Box{}, fmt.Errorf("region %q must be written as x1,y1,z1:x2,y2,z2", s)
+	}
+	a, err := ParsePos(corners[0])
+	if err != nil {
+		return Box{}, err
+	}
+	b, err := ParsePos(corners[1])
+	if err != nil {
+		return Box{}, err
+	}
+	return NewBox(a, b), nil
+}
+
+// NewBox returns the box spanning two opposite corners.
+func NewBox(a, b Pos) Box {
+	return Box{
+		Min: Pos{X: minInt(a.X, b.X), Y: minInt(a.Y, b.Y), Z: minInt(a.Z, b.Z)},
+		Max: Pos{X: maxInt(a.X, b.X), Y: maxInt(a.Y, b.Y), Z: maxInt(a.Z, b.Z)},
+	}
+}
+
+// Include returns the smallest box holding both b and p.
+func (b Box) Include(p Pos) Box {
+	box := NewBox(NewBox(b.Min, p).Min, NewBox(b.Max, p).Max)
+	box.Block = b.Block
+	return box
+}
+
+// String renders the box as "x1,y1,z1:x2,y2,z2", the form ParseBox reads.
+func (b Box) String() string {
+	return b.Min.String() + ":" + b.Max.String()
+}
+
+// Volume returns the number of blocks in the box.
+func (b Box) Volume() int {
+	return (b.Max.X - b.Min.X + 1) * (b.Max.Y - b.Min.Y + 1) * (b.Max.Z - b.Min.Z + 1)
+}
+
+// SortedPositions returns the positions in blocks ordered by Y, then Z, then X.
+func SortedPositions(blocks map[Pos]string) []Pos {
+	positions := make([]Pos, 0, len(blocks))
+	for p := range blocks {
+		positions = append(positions, p)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		a, b := positions[i], positions[j]
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		if a.Z != b.Z {
+			return a.Z < b.Z
+		}
+		return a.X < b.X
+	})
+	return positions
+}
+
+// Coalesce merges adjacent identical blocks into boxes so a build can be
+// written with few `fill` commands. Runs are grown greedily along X, then Z,
+// then Y, and no box exceeds MaxFillVolume. The result is deterministic.
+func Coalesce(blocks map[Pos]string) []Box {
+	done := make(map[Pos]bool, len(blocks))
+	free := func(p Pos, block string) bool {
+		b, ok := blocks[p]
+		return ok && b == block && !done[p]
+	}
+
+	var boxes []Box
+	for _, start := range SortedPositions(blocks) {
+		if done[start] {
+			continue
+		}
+		block := blocks[start]
+		max := start
+
+		for free(Pos{max.X + 1, start.Y, start.Z}, block) && max.X-start.X+2 <= MaxFillVolume {
+			max.X++
+		}
+		width := max.X - start.X + 1
+
+	growZ:
+		for (max.Z-start.Z+2)*width <= MaxFillVolume {
+			for x := start.X; x <= max.X; x++ {
+				if !free(Pos{x, start.Y, max.Z + 1}, block) {
+					break growZ
+				}
+			}
+			max.Z++
+		}
+		area := width * (max.Z - start.Z + 1)
+
+	growY:
+		for (max.Y-start.Y+2)*area <= MaxFillVolume {
+			for z := start.Z; z <= max.Z; z++ {
+				for x := start.X; x <= max.X; x++ {
+					if !free(Pos{x, max.Y + 1, z}, block) {
+						break growY
+					}
+				}
+			}
+			max.Y++
+		}
+
+		for y := start.Y; y <= max.Y; y++ {
+			for z := start.Z; z <= max.Z; z++ {
+				for x := start.X; x <= max.X; x++ {
+					done[Pos{x, y, z}] = true
+				}
+			}
+		}
+		boxes = append(boxes, Box{Min: start, Max: max, Block: block})
+	}
+	return boxes
+}
+
+// SplitBox cuts a box of any size into boxes of at most MaxFillVolume
+// blocks, slicing whole layers along Y where it can.
+func SplitBox(box Box) []Box {
+	return splitBox(box, MaxFillVolume)
+}
+
+// splitBox cuts box into boxes of at most limit positions.
+func splitBox(box Box, limit int) []Box {
+	size := Pos{box.Max.X - box.Min.X + 1, box.Max.Y - box.Min.Y + 1, box.Max.Z - box.Min.Z + 1}
+	step := size
+	if step.X*step.Z > limit {
+		step.X = minInt(step.X, limit)
+		step.Z = maxInt(1, minInt(step.Z, limit/step.X))
+	}
+	step.Y = maxInt(1, minInt(step.Y, limit/(step.X*step.Z)))
+
+	var boxes []Box
+	for y := box.Min.Y; y <= box.Max.Y; y += step.Y {
+		for z := box.Min.Z; z <= box.Max.Z; z += step.Z {
+			for x := box.Min.X; x <= box.Max.X; x += step.X {
+				boxes = append(boxes, Box{
+					Min:   Pos{x, y, z},
+					Max:   Pos{minInt(x+step.X-1, box.Max.X), minInt(y+step.Y-1, box.Max.Y), minInt(z+step.Z-1, box.Max.Z)},
+					Block: box.Block,
+				})
+			}
+		}
+	}
+	return boxes
+}
+
+// FillBox writes a box with `fill … replace`, or `setblock` for a single block.
+func (c Client) FillBox(ctx context.Context, box Box) error {
+	if box.Min == box.Max {
+		return c.CreateBlock(ctx, box.Block, box.Min.X, box.Min.Y, box.Min.Z)
+	}
+	out, err := c.send(fmt.Sprintf("fill %d %d %d %d %d %d %s replace",
+		box.Min.X, box.Min.Y, box.Min.Z, box.Max.X, box.Max.Y, box.Max.Z, box.Block))
+	if err != nil {
+		return err
+	}
+	// "Successfully filled 27 block(s)", or "No blocks were filled" when
+	// every block already matched.
+	if !strings.Contains(out, "Successfully filled") && !strings.Contains(out, "No blocks were filled") {
+		return fmt.Errorf("fill %s %s: %s", box.Min, box.Max, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// SetBlocks writes every block in the map using as few commands as
+// Coalesce allows and returns the number of commands sent.
+func (c Client) SetBlocks(ctx context.Context, blocks map[Pos]string) (int, error) {
+	boxes := Coalesce(blocks)
+	for i, box := range boxes {
+		if err := c.FillBox(ctx, box); err != nil {
+			return i, err
+		}
+	}
+	return len(boxes), nil
+}
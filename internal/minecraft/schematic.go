@@ -0,0 +1,579 @@
+package minecraft
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// Schematic is a build read from a Sponge schematic (.schem) or a vanilla
+// structure file (.nbt). Positions are offsets from the build's
+// north-west bottom corner.
+type Schematic struct {
+	DataVersion   int
+	Size          Pos
+	Blocks        map[Pos]string       // full block states, air included
+	BlockEntities map[Pos]nbt.Compound // data to merge, without id or position
+	Entities      []SchematicEntity
+}
+
+// SchematicEntity is an entity saved with a schematic.
+type SchematicEntity struct {
+	Pos [3]float64
+	ID  string
+	NBT nbt.Compound // without id, position or UUID
+}
+
+// ReadSchematic reads a Sponge schematic (version 1 to 3) or a vanilla
+// structure file, gzipped or not.
+func ReadSchematic(path string) (*Schematic, error) {
+	_, root, err := nbt.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s, err := ParseSchematic(root)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// ParseSchematic decodes the root compound of a schematic or structure file.
+func ParseSchematic(root nbt.Compound) (*Schematic, error) {
+	// Sponge v3 wraps everything in a "Schematic" compound; v1 and v2 name
+	// the root compound instead.
+	if inner, ok := root["Schematic"].(nbt.Compound); ok {
+		root = inner
+	}
+
+	switch {
+	case root["BlockData"] != nil || root["Blocks"] != nil && root["Width"] != nil:
+		return parseSponge(root)
+	case root["blocks"] != nil && root["size"] != nil:
+		return parseStructure(root)
+	}
+	return nil, fmt.Errorf("not a Sponge schematic or structure file")
+}
+
+func parseSponge(root nbt.Compound) (*Schematic, error) {
+	version, _ := tagInt(root["Version"])
+	width, _ := tagInt(root["Width"])
+	height, _ := tagInt(root["Height"])
+	length, _ := tagInt(root["Length"])
+	dataVersion, _ := tagInt(root["DataVersion"])
+	// Sizes are unsigned shorts.
+	if width < 0 {
+		width += 1 << 16
+	}
+	if height < 0 {
+		height += 1 << 16
+	}
+	if length < 0 {
+		length += 1 << 16
+	}
+	if width == 0 || height == 0 || length == 0 {
+		return nil, fmt.Errorf("invalid size %dx%dx%d", width, height, length)
+	}
+
+	s := &Schematic{
+		DataVersion:   dataVersion,
+		Size:          Pos{width, height, length},
+		Blocks:        map[Pos]string{},
+		BlockEntities: map[Pos]nbt.Compound{},
+	}
+
+	// Version 3 moved the palette, block data and block entities into a
+	// "Blocks" container and nested extra data under "Data".
+	container := root
+	blockData := "BlockData"
+	if version >= 3 {
+		blocks, ok := root["Blocks"].(nbt.Compound)
+		if !ok {
+			return s, nil // a schematic of entities only
+		}
+		container, blockData = blocks, "Data"
+	}
+
+	palette := map[int]string{}
+	paletteTag, _ := container["Palette"].(nbt.Compound)
+	for block, v := range paletteTag {
+		if i, ok := tagInt(v); ok {
+			palette[i] = block
+		}
+	}
+
+	data, _ := container[blockData].([]byte)
+	index, i := 0, 0
+	for i < len(data) {
+		// Palette indexes are stored as unsigned LEB128 varints.
+		value, shift := 0, uint(0)
+		for {
+			if i >= len(data) {
+				return nil, fmt.Errorf("truncated block data")
+			}
+			b := data[i]
+			i++
+			value |= int(b&0x7f) << shift
+			if b&0x80 == 0 {
+				break
+			}
+			shift += 7
+			if shift > 28 {
+				return nil, fmt.Errorf("invalid varint in block data")
+			}
+		}
+
+		block, ok := palette[value]
+		if !ok {
+			return nil, fmt.Errorf("block data refers to palette index %d, which is not in the palette", value)
+		}
+		p := Pos{X: index % width, Y: index / (width * length), Z: (index / width) % length}
+		if p.Y >= height {
+			return nil, fmt.Errorf("block data is longer than %dx%dx%d", width, height, length)
+		}
+		if block != "minecraft:structure_void" {
+			s.Blocks[p] = block
+		}
+		index++
+	}
+
+	entitiesKey := "BlockEntities"
+	if version == 1 {
+		entitiesKey = "TileEntities"
+	}
+	blockEntities, _ := container[entitiesKey].(nbt.List)
+	for _, item := range blockEntities.Items {
+		c, ok := item.(nbt.Compound)
+		if !ok {
+			continue
+		}
+		pos, ok := c["Pos"].([]int32)
+		if !ok || len(pos) != 3 {
+			continue
+		}
+		data := c
+		if version >= 3 {
+			data, _ = c["Data"].(nbt.Compound)
+		}
+		s.BlockEntities[Pos{int(pos[0]), int(pos[1]), int(pos[2])}] = without(data, "Id", "id", "Pos", "x", "y", "z", "keepPacked")
+	}
+
+	entities, _ := root["Entities"].(nbt.List)
+	for _, item := range entities.Items {
+		c, ok := item.(nbt.Compound)
+		if !ok {
+			continue
+		}
+		pos, ok := tagVector(c["Pos"])
+		id, _ := c["Id"].(string)
+		if !ok || id == "" {
+			continue
+		}
+		data := c
+		if version >= 3 {
+			data, _ = c["Data"].(nbt.Compound)
+		}
+		s.Entities = append(s.Entities, SchematicEntity{Pos: pos, ID: id, NBT: entityData(data)})
+	}
+	return s, nil
+}
+
+func parseStructure(root nbt.Compound) (*Schematic, error) {
+	size, ok := tagVector(root["size"])
+	if !ok || size[0] <= 0 || size[1] <= 0 || size[2] <= 0 {
+		return nil, fmt.Errorf("invalid size")
+	}
+	dataVersion, _ := tagInt(root["DataVersion"])
+	s := &Schematic{
+		DataVersion:   dataVersion,
+		Size:          Pos{int(size[0]), int(size[1]), int(size[2])},
+		Blocks:        map[Pos]string{},
+		BlockEntities: map[Pos]nbt.Compound{},
+	}
+
+	// Structures with random variants (e.g. shipwrecks) keep several
+	// palettes; the first one is used.
+	paletteTag, ok := root["palette"].(nbt.List)
+	if !ok {
+		if palettes, ok := root["palettes"].(nbt.List); ok && len(palettes.Items) > 0 {
+			paletteTag, _ = palettes.Items[0].(nbt.List)
+		}
+	}
+	var palette []string
+	for _, item := range paletteTag.Items {
+		c, _ := item.(nbt.Compound)
+		name, _ := c["Name"].(string)
+		state := map[string]string{}
+		props, _ := c["Properties"].(nbt.Compound)
+		for k, v := range props {
+			if str, ok := v.(string); ok {
+				state[k] = str
+			}
+		}
+		palette = append(palette, BlockWithState(name, state))
+	}
+
+	blocks, _ := root["blocks"].(nbt.List)
+	for _, item := range blocks.Items {
+		c, ok := item.(nbt.Compound)
+		if !ok {
+			continue
+		}
+		pos, ok := tagVector(c["pos"])
+		state, _ := tagInt(c["state"])
+		if !ok || state < 0 || state >= len(palette) {
+			return nil, fmt.Errorf("block refers to palette index %d, which is not in the palette", state)
+		}
+		p := Pos{int(pos[0]), int(pos[1]), int(pos[2])}
+		if palette[state] == "minecraft:structure_void" {
+			continue
+		}
+		s.Blocks[p] = palette[state]
+		if data, ok := c["nbt"].(nbt.Compound); ok {
+			s.BlockEntities[p] = without(data, "id", "x", "y", "z", "keepPacked")
+		}
+	}
+
+	entities, _ := root["entities"].(nbt.List)
+	for _, item := range entities.Items {
+		c, ok := item.(nbt.Compound)
+		if !ok {
+			continue
+		}
+		pos, ok := tagVector(c["pos"])
+		data, _ := c["nbt"].(nbt.Compound)
+		id, _ := data["id"].(string)
+		if !ok || id == "" {
+			continue
+		}
+		s.Entities = append(s.Entities, SchematicEntity{Pos: pos, ID: id, NBT: entityData(data)})
+	}
+	return s, nil
+}
+
+// WriteSchematic writes s as a gzipped Sponge schematic. Version 2 is used
+// as it is read by every WorldEdit release since 1.13 as well as by
+// minecraft_schematic.
+func WriteSchematic(path string, s *Schematic) error {
+	return nbt.WriteFile(path, "Schematic", s.Sponge())
+}
+
+// Sponge encodes s as the root compound of a version 2 Sponge schematic.
+// Positions missing from Blocks are written as air.
+func (s *Schematic) Sponge() nbt.Compound {
+	palette := nbt.Compound{"minecraft:air": int32(0)}
+	var data []byte
+	for y := 0; y < s.Size.Y; y++ {
+		for z := 0; z < s.Size.Z; z++ {
+			for x := 0; x < s.Size.X; x++ {
+				block, ok := s.Blocks[Pos{x, y, z}]
+				if !ok {
+					block = "minecraft:air"
+				}
+				index, ok := palette[block].(int32)
+				if !ok {
+					index = int32(len(palette))
+					palette[block] = index
+				}
+				// Palette indexes are unsigned LEB128 varints.
+				for v := uint32(index); ; v >>= 7 {
+					if v < 0x80 {
+						data = append(data, byte(v))
+						break
+					}
+					data = append(data, byte(v&0x7f|0x80))
+				}
+			}
+		}
+	}
+
+	blockEntities := nbt.List{Type: nbt.TagCompound}
+	for _, p := range sortedBlockEntities(s.BlockEntities) {
+		c := nbt.Compound{}
+		for k, v := range s.BlockEntities[p] {
+			c[k] = v
+		}
+		if _, ok := c["id"]; !ok {
+			id, _ := ParseBlock(s.Blocks[p])
+			c["Id"] = blockEntityID(id)
+		} else {
+			c["Id"] = c["id"]
+			delete(c, "id")
+		}
+		c["Pos"] = []int32{int32(p.X), int32(p.Y), int32(p.Z)}
+		blockEntities.Items = append(blockEntities.Items, c)
+	}
+
+	entities := nbt.List{Type: nbt.TagCompound}
+	for _, e := range s.Entities {
+		c := nbt.Compound{}
+		for k, v := range e.NBT {
+			c[k] = v
+		}
+		c["Id"] = e.ID
+		c["Pos"] = nbt.List{Type: nbt.TagDouble, Items: []interface{}{e.Pos[0], e.Pos[1], e.Pos[2]}}
+		entities.Items = append(entities.Items, c)
+	}
+
+	return nbt.Compound{
+		"Version":       int32(2),
+		"DataVersion":   int32(s.DataVersion),
+		"Width":         int16(s.Size.X),
+		"Height":        int16(s.Size.Y),
+		"Length":        int16(s.Size.Z),
+		"Offset":        []int32{0, 0, 0},
+		"PaletteMax":    int32(len(palette)),
+		"Palette":       palette,
+		"BlockData":     data,
+		"BlockEntities": blockEntities,
+		"Entities":      entities,
+	}
+}
+
+func sortedBlockEntities(m map[Pos]nbt.Compound) []Pos {
+	blocks := make(map[Pos]string, len(m))
+	for p := range m {
+		blocks[p] = ""
+	}
+	return SortedPositions(blocks)
+}
+
+// blockEntityID returns the block entity type of a block, which for most
+// blocks is the block's own ID.
+func blockEntityID(block string) string {
+	name := strings.TrimPrefix(block, "minecraft:")
+	switch {
+	case strings.HasSuffix(name, "_hanging_sign"):
+		return "minecraft:hanging_sign"
+	case strings.HasSuffix(name, "_sign"):
+		return "minecraft:sign"
+	case strings.HasSuffix(name, "_bed"):
+		return "minecraft:bed"
+	case strings.HasSuffix(name, "_banner"):
+		return "minecraft:banner"
+	case strings.HasSuffix(name, "shulker_box"):
+		return "minecraft:shulker_box"
+	case strings.HasSuffix(name, "_head") || strings.HasSuffix(name, "_skull"):
+		return "minecraft:skull"
+	}
+	return block
+}
+
+// Place returns the schematic's blocks, block entities and entities turned
+// by t and moved so the corner of the transformed build is at origin.
+func (s *Schematic) Place(origin Pos, t Transform) (map[Pos]string, map[Pos]nbt.Compound, []SchematicEntity) {
+	at := func(p Pos) Pos {
+		q := t.Apply(p, s.Size)
+		return Pos{origin.X + q.X, origin.Y + q.Y, origin.Z + q.Z}
+	}
+
+	blocks := make(map[Pos]string, len(s.Blocks))
+	cache := map[string]string{}
+	for p, block := range s.Blocks {
+		turned, ok := cache[block]
+		if !ok {
+			turned = t.Block(block)
+			cache[block] = turned
+		}
+		blocks[at(p)] = turned
+	}
+
+	blockEntities := make(map[Pos]nbt.Compound, len(s.BlockEntities))
+	for p, data := range s.BlockEntities {
+		blockEntities[at(p)] = data
+	}
+
+	entities := make([]SchematicEntity, 0, len(s.Entities))
+	for _, e := range s.Entities {
+		q := t.Point(e.Pos, s.Size)
+		pos := [3]float64{float64(origin.X) + q[0], float64(origin.Y) + q[1], float64(origin.Z) + q[2]}
+		data := without(e.NBT)
+		if rotation, ok := data["Rotation"].(nbt.List); ok && len(rotation.Items) == 2 {
+			if yaw, ok := rotation.Items[0].(float32); ok {
+				data["Rotation"] = nbt.List{Type: nbt.TagFloat, Items: []interface{}{t.Yaw(yaw), rotation.Items[1]}}
+			}
+		}
+		// Hanging entities such as item frames and paintings are attached
+		// to the block they hang on.
+		if _, ok := data["TileX"]; ok {
+			data["TileX"] = int32(math.Floor(pos[0]))
+			data["TileY"] = int32(math.Floor(pos[1]))
+			data["TileZ"] = int32(math.Floor(pos[2]))
+		}
+		if facing, ok := data["Facing"].(int8); ok && facing >= 2 && facing <= 5 {
+			data["Facing"] = directionIDs[t.Facing(directionNames[facing])]
+		}
+		entities = append(entities, SchematicEntity{Pos: pos, ID: e.ID, NBT: data})
+	}
+	return blocks, blockEntities, entities
+}
+
+// directionNames are the direction IDs hanging entities store in Facing.
+var directionNames = map[int8]string{0: "down", 1: "up", 2: "north", 3: "south", 4: "west", 5: "east"}
+
+var directionIDs = map[string]int8{"down": 0, "up": 1, "north": 2, "south": 3, "west": 4, "east": 5}
+
+// entityData strips what `summon` must not be given: the entity's own ID,
+// position and UUID.
+func entityData(c nbt.Compound) nbt.Compound {
+	return without(c, "id", "Id", "Pos", "UUID", "UUIDMost", "UUIDLeast")
+}
+
+func without(c nbt.Compound, keys ...string) nbt.Compound {
+	out := make(nbt.Compound, len(c))
+	for k, v := range c {
+		if !containsString(keys, k) {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func tagInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+// tagVector reads a three-element list of numbers or an int array.
+func tagVector(v interface{}) ([3]float64, bool) {
+	var out [3]float64
+	var items []interface{}
+	switch v := v.(type) {
+	case nbt.List:
+		items = v.Items
+	case []int32:
+		for _, n := range v {
+			items = append(items, n)
+		}
+	}
+	if len(items) != 3 {
+		return out, false
+	}
+	for i, item := range items {
+		switch n := item.(type) {
+		case float32:
+			out[i] = float64(n)
+		case float64:
+			out[i] = n
+		default:
+			f, ok := tagInt(item)
+			if !ok {
+				return out, false
+			}
+			out[i] = float64(f)
+		}
+	}
+	return out, true
+}
+
+// dataVersions maps world data versions to the release that introduced them.
+var dataVersions = []struct {
+	data    int
+	version string
+}{
+	{1519, "1.13"}, {1952, "1.14"}, {2225, "1.15"}, {2566, "1.16"},
+	{2724, "1.17"}, {2860, "1.18"}, {3105, "1.19"}, {3218, "1.19.3"},
+	{3337, "1.19.4"}, {3463, "1.20"}, {3578, "1.20.2"}, {3698, "1.20.3"},
+	{3837, "1.20.5"}, {3953, "1.21"}, {4080, "1.21.2"}, {4189, "1.21.4"},
+}
+
+// VersionForData returns the release a data version belongs to, or false
+// for data versions older than 1.13.
+func VersionForData(data int) (Version, bool) {
+	for i := len(dataVersions) - 1; i >= 0; i-- {
+		if data >= dataVersions[i].data {
+			return MustParseVersion(dataVersions[i].version), true
+		}
+	}
+	return Version{}, false
+}
+
+// DataVersionFor returns the data version worlds saved by version carry,
+// rounded down to the last release in the table.
+func DataVersionFor(version Version) int {
+	for i := len(dataVersions) - 1; i >= 0; i-- {
+		if version.AtLeast(MustParseVersion(dataVersions[i].version)) {
+			return dataVersions[i].data
+		}
+	}
+	return dataVersions[0].data
+}
+
+// blockRenames are blocks that changed ID since the 1.13 flattening.
+var blockRenames = []struct {
+	since    string
+	old, new string
+}{
+	{"1.14", "minecraft:sign", "minecraft:oak_sign"},
+	{"1.14", "minecraft:wall_sign", "minecraft:oak_wall_sign"},
+	{"1.14", "minecraft:stone_slab", "minecraft:smooth_stone_slab"},
+	{"1.17", "minecraft:grass_path", "minecraft:dirt_path"},
+	{"1.20.3", "minecraft:grass", "minecraft:short_grass"},
+}
+
+// Translate rewrites the schematic's blocks for a server running target:
+// renamed blocks get the name the server knows, and wall connections are
+// converted between the pre-1.16 true/false and the later none/low/tall.
+// Schematics without a data version are assumed to match the server.
+func (s *Schematic) Translate(target Version) error {
+	if s.DataVersion == 0 {
+		return nil
+	}
+	source, ok := VersionForData(s.DataVersion)
+	if !ok {
+		return fmt.Errorf("schematics from before Minecraft 1.13 (data version %d) are not supported", s.DataVersion)
+	}
+
+	cache := map[string]string{}
+	for p, block := range s.Blocks {
+		translated, ok := cache[block]
+		if !ok {
+			translated = translateBlock(block, source, target)
+			cache[block] = translated
+		}
+		s.Blocks[p] = translated
+	}
+	return nil
+}
+
+func translateBlock(block string, source, target Version) string {
+	id, state := ParseBlock(block)
+	for _, r := range blockRenames {
+		since := MustParseVersion(r.since)
+		switch {
+		case id == r.old && !source.AtLeast(since) && target.AtLeast(since):
+			id = r.new
+		case id == r.new && source.AtLeast(since) && !target.AtLeast(since):
+			id = r.old
+		}
+	}
+
+	walls := MustParseVersion("1.16")
+	if strings.HasSuffix(id, "_wall") && source.AtLeast(walls) != target.AtLeast(walls) {
+		for _, side := range []string{"north", "east", "south", "west"} {
+			v, ok := state[side]
+			if !ok {
+				continue
+			}
+			conversion := map[string]string{"none": "false", "low": "true", "tall": "true"}
+			if target.AtLeast(walls) {
+				conversion = map[string]string{"true": "low", "false": "none"}
+			}
+			if converted, ok := conversion[v]; ok {
+				state[side] = converted
+			}
+		}
+	}
+	return BlockWithState(id, state)
+}
@@ -0,0 +1,327 @@
+package minecraft
+
+import (
+	"fmt"
+	"math"
+)
+
+// ShapeTypes are the primitives Shape can rasterise.
+var ShapeTypes = []string{"sphere", "ellipsoid", "cylinder", "cone", "pyramid", "torus", "line"}
+
+// maxShapeVolume caps the bounding box a shape may cover so a typo in a
+// radius can't exhaust memory.
+const maxShapeVolume = 1 << 24
+
+// Shape describes a geometric primitive to rasterise into blocks.
+//
+// Center is the centre of a sphere, ellipsoid or torus, the centre of the
+// base of a cylinder, cone or pyramid, and the start of a line. Cylinders,
+// cones and pyramids grow Height blocks along Axis; a torus lies flat
+// around Axis.
+type Shape struct {
+	Type        string
+	Center      Pos
+	Radius      int
+	Radii       Pos // ellipsoid radii along X, Y and Z
+	Height      int
+	MinorRadius int // torus tube radius
+	End         Pos // line end
+	Axis        string
+	Hollow      bool
+	Thickness   int // shell thickness when hollow, or line width
+}
+
+// Validate checks the shape has the parameters its type needs.
+func (s Shape) Validate() error {
+	if !containsString(ShapeTypes, s.Type) {
+		return fmt.Errorf("type must be one of: sphere, ellipsoid, cylinder, cone, pyramid, torus, line (got %q)", s.Type)
+	}
+	switch s.axis() {
+	case "x", "y", "z":
+	default:
+		return fmt.Errorf("axis must be one of x, y, z (got %q)", s.Axis)
+	}
+	if s.Thickness < 0 {
+		return fmt.Errorf("thickness must not be negative")
+	}
+
+	switch s.Type {
+	case "sphere":
+		if s.Radius < 0 {
+			return fmt.Errorf("a sphere needs a radius of 0 or more")
+		}
+	case "ellipsoid":
+		if s.Radii.X < 0 || s.Radii.Y < 0 || s.Radii.Z < 0 {
+			return fmt.Errorf("an ellipsoid needs radii of 0 or more")
+		}
+	case "cylinder", "cone", "pyramid":
+		if s.Radius < 0 || s.Height < 1 {
+			return fmt.Errorf("a %s needs a radius of 0 or more and a height of 1 or more", s.Type)
+		}
+	case "torus":
+		if s.MinorRadius < 0 || s.Radius < s.MinorRadius {
+			return fmt.Errorf("a torus needs a minor_radius of 0 or more and a radius at least as large")
+		}
+	}
+
+	min, max := s.bounds()
+	volume := float64(max.X-min.X+1) * float64(max.Y-min.Y+1) * float64(max.Z-min.Z+1)
+	if volume > maxShapeVolume {
+		return fmt.Errorf("the %s spans %.0f blocks, more than the limit of %d", s.Type, volume, maxShapeVolume)
+	}
+	return nil
+}
+
+// Voxels rasterises the shape into block positions.
+func (s Shape) Voxels() ([]Pos, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if s.Type == "line" {
+		return s.line(), nil
+	}
+
+	thickness := s.Thickness
+	if thickness == 0 {
+		thickness = 1
+	}
+
+	min, max := s.bounds()
+	var voxels []Pos
+	for y := min.Y; y <= max.Y; y++ {
+		for z := min.Z; z <= max.Z; z++ {
+			for x := min.X; x <= max.X; x++ {
+				p := Pos{x, y, z}
+				if !s.inside(p) {
+					continue
+				}
+				if s.Hollow && s.interior(p, thickness) {
+					continue
+				}
+				voxels = append(voxels, p)
+			}
+		}
+	}
+	return voxels, nil
+}
+
+func (s Shape) axis() string {
+	if s.Axis == "" {
+		return "y"
+	}
+	return s.Axis
+}
+
+// local maps an offset from Center into (a, b) across the axis and w along it.
+func (s Shape) local(p Pos) (a, b, w int) {
+	dx, dy, dz := p.X-s.Center.X, p.Y-s.Center.Y, p.Z-s.Center.Z
+	switch s.axis() {
+	case "x":
+		return dy, dz, dx
+	case "z":
+		return dx, dy, dz
+	default:
+		return dx, dz, dy
+	}
+}
+
+// world maps local (a, b, w) back to a position.
+func (s Shape) world(a, b, w int) Pos {
+	switch s.axis() {
+	case "x":
+		return Pos{s.Center.X + w, s.Center.Y + a, s.Center.Z + b}
+	case "z":
+		return Pos{s.Center.X + a, s.Center.Y + b, s.Center.Z + w}
+	default:
+		return Pos{s.Center.X + a, s.Center.Y + w, s.Center.Z + b}
+	}
+}
+
+// bounds returns the inclusive bounding box of the shape.
+func (s Shape) bounds() (Pos, Pos) {
+	var lo, hi Pos
+	switch s.Type {
+	case "sphere":
+		r := s.Radius
+		return Pos{s.Center.X - r, s.Center.Y - r, s.Center.Z - r}, Pos{s.Center.X + r, s.Center.Y + r, s.Center.Z + r}
+	case "ellipsoid":
+		r := s.Radii
+		return Pos{s.Center.X - r.X, s.Center.Y - r.Y, s.Center.Z - r.Z}, Pos{s.Center.X + r.X, s.Center.Y + r.Y, s.Center.Z + r.Z}
+	case "cylinder", "cone", "pyramid":
+		lo, hi = s.world(-s.Radius, -s.Radius, 0), s.world(s.Radius, s.Radius, s.Height-1)
+	case "torus":
+		r := s.Radius + s.MinorRadius
+		lo, hi = s.world(-r, -r, -s.MinorRadius), s.world(r, r, s.MinorRadius)
+	case "line":
+		lo, hi = s.Center, s.End
+	}
+	return Pos{minInt(lo.X, hi.X), minInt(lo.Y, hi.Y), minInt(lo.Z, hi.Z)},
+		Pos{maxInt(lo.X, hi.X), maxInt(lo.Y, hi.Y), maxInt(lo.Z, hi.Z)}
+}
+
+// inside reports whether the block at p belongs to the solid shape. Radii
+// are padded by half a block so small shapes come out round.
+func (s Shape) inside(p Pos) bool {
+	a, b, w := s.local(p)
+	fa, fb, fw := float64(a), float64(b), float64(w)
+
+	switch s.Type {
+	case "sphere":
+		r := float64(s.Radius) + 0.5
+		dx, dy, dz := float64(p.X-s.Center.X), float64(p.Y-s.Center.Y), float64(p.Z-s.Center.Z)
+		return dx*dx+dy*dy+dz*dz <= r*r
+	case "ellipsoid":
+		rx, ry, rz := float64(s.Radii.X)+0.5, float64(s.Radii.Y)+0.5, float64(s.Radii.Z)+0.5
+		dx, dy, dz := float64(p.X-s.Center.X)/rx, float64(p.Y-s.Center.Y)/ry, float64(p.Z-s.Center.Z)/rz
+		return dx*dx+dy*dy+dz*dz <= 1
+	case "cylinder":
+		r := float64(s.Radius) + 0.5
+		return w >= 0 && w < s.Height && fa*fa+fb*fb <= r*r
+	case "cone":
+		if w < 0 || w >= s.Height {
+			return false
+		}
+		r := float64(s.Radius)*float64(s.Height-w)/float64(s.Height) + 0.5
+		return fa*fa+fb*fb <= r*r
+	case "pyramid":
+		if w < 0 || w >= s.Height {
+			return false
+		}
+		half := s.Radius
+		if s.Height > 1 {
+			half = s.Radius * (s.Height - 1 - w) / (s.Height - 1)
+		}
+		return absInt(a) <= half && absInt(b) <= half
+	case "torus":
+		r := float64(s.MinorRadius) + 0.5
+		d := math.Sqrt(fa*fa+fb*fb) - float64(s.Radius)
+		return d*d+fw*fw <= r*r
+	}
+	return false
+}
+
+// interior reports whether p is at least thickness blocks inside the shape
+// along every axis, i.e. not part of a hollow shell.
+func (s Shape) interior(p Pos, thickness int) bool {
+	for k := 1; k <= thickness; k++ {
+		for _, d := range []Pos{{k, 0, 0}, {-k, 0, 0}, {0, k, 0}, {0, -k, 0}, {0, 0, k}, {0, 0, -k}} {
+			if !s.inside(Pos{p.X + d.X, p.Y + d.Y, p.Z + d.Z}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// line walks from Center to End with a 3D Bresenham and widens each step
+// into a Thickness-sized cube.
+func (s Shape) line() []Pos {
+	width := s.Thickness
+	if width == 0 {
+		width = 1
+	}
+	lo := -(width - 1) / 2
+	hi := width / 2
+
+	seen := map[Pos]bool{}
+	var voxels []Pos
+	for _, c := range bresenham(s.Center, s.End) {
+		for dy := lo; dy <= hi; dy++ {
+			for dz := lo; dz <= hi; dz++ {
+				for dx := lo; dx <= hi; dx++ {
+					p := Pos{c.X + dx, c.Y + dy, c.Z + dz}
+					if !seen[p] {
+						seen[p] = true
+						voxels = append(voxels, p)
+					}
+				}
+			}
+		}
+	}
+	return voxels
+}
+
+func bresenham(from, to Pos) []Pos {
+	dx, dy, dz := absInt(to.X-from.X), absInt(to.Y-from.Y), absInt(to.Z-from.Z)
+	sx, sy, sz := signInt(to.X-from.X), signInt(to.Y-from.Y), signInt(to.Z-from.Z)
+	steps := maxInt(dx, maxInt(dy, dz))
+
+	points := make([]Pos, 0, steps+1)
+	p := from
+	points = append(points, p)
+	e1, e2 := 2*dy-steps, 2*dz-steps
+	e0 := 2*dx - steps
+	for i := 0; i < steps; i++ {
+		switch steps {
+		case dx:
+			if e1 >= 0 {
+				p.Y += sy
+				e1 -= 2 * dx
+			}
+			if e2 >= 0 {
+				p.Z += sz
+				e2 -= 2 * dx
+			}
+			e1 += 2 * dy
+			e2 += 2 * dz
+			p.X += sx
+		case dy:
+			if e0 >= 0 {
+				p.X += sx
+				e0 -= 2 * dy
+			}
+			if e2 >= 0 {
+				p.Z += sz
+				e2 -= 2 * dy
+			}
+			e0 += 2 * dx
+			e2 += 2 * dz
+			p.Y += sy
+		default:
+			if e0 >= 0 {
+				p.X += sx
+				e0 -= 2 * dz
+			}
+			if e1 >= 0 {
+				p.Y += sy
+				e1 -= 2 * dz
+			}
+			e0 += 2 * dx
+			e1 += 2 * dy
+			p.Z += sz
+		}
+		points = append(points, p)
+	}
+	return points
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func signInt(n int) int {
+	switch {
+	case n > 0:
+		return 1
+	case n < 0:
+		return -1
+	}
+	return 0
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
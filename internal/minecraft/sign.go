@@ -0,0 +1,184 @@
+package minecraft
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// Sign block entity data changed shape in 1.20: one `Text1..4` set per sign
+// became `front_text`/`back_text` compounds, and signs could be waxed.
+var (
+	signSidesVersion   = MustParseVersion("1.20")
+	signGlowingVersion = MustParseVersion("1.17")
+)
+
+// SignText is the text on one side of a sign.
+type SignText struct {
+	Lines   []string
+	Color   string
+	Glowing bool
+}
+
+// Sign is the block entity data of a sign.
+type Sign struct {
+	Front SignText
+	Back  SignText
+	Waxed bool
+}
+
+// SignSupportsSides reports whether the server has two-sided, waxable signs.
+func SignSupportsSides(version Version) bool {
+	return version.AtLeast(signSidesVersion)
+}
+
+// SignSupportsGlowing reports whether the server has glowing sign text.
+func SignSupportsGlowing(version Version) bool {
+	return version.AtLeast(signGlowingVersion)
+}
+
+// SignNBT renders the sign as block entity SNBT for the given server version.
+// Before 1.20 only the front text is written.
+func SignNBT(version Version, sign Sign) string {
+	if !SignSupportsSides(version) {
+		tags := make([]string, 0, 6)
+		for i, line := range paddedSignLines(sign.Front.Lines) {
+			tags = append(tags, fmt.Sprintf("Text%d:%s", i+1, QuoteSNBT(textComponent(line))))
+		}
+		tags = append(tags, fmt.Sprintf("Color:%q", signColor(sign.Front.Color)))
+		if SignSupportsGlowing(version) {
+			tags = append(tags, fmt.Sprintf("GlowingText:%s", nbtBool(sign.Front.Glowing)))
+		}
+		return "{" + strings.Join(tags, ",") + "}"
+	}
+
+	return fmt.Sprintf("{front_text:%s,back_text:%s,is_waxed:%s}",
+		signSideNBT(sign.Front), signSideNBT(sign.Back), nbtBool(sign.Waxed))
+}
+
+// ParseSignNBT reads sign text back from block entity SNBT as returned by
+// GetBlockData.
+func ParseSignNBT(snbt string) (Sign, error) {
+	tag, err := nbt.ParseCompound(snbt)
+	if err != nil {
+		return Sign{}, err
+	}
+
+	var sign Sign
+	if _, ok := tag["front_text"]; ok {
+		sign.Front = parseSignSide(tag["front_text"])
+		sign.Back = parseSignSide(tag["back_text"])
+		sign.Waxed = nbtInt(tag["is_waxed"]) != 0
+		return sign, nil
+	}
+
+	// Pre-1.20 layout.
+	for i := 1; i <= 4; i++ {
+		s, _ := tag[fmt.Sprintf("Text%d", i)].(string)
+		sign.Front.Lines = append(sign.Front.Lines, plainText(s))
+	}
+	sign.Front.Color, _ = tag["Color"].(string)
+	sign.Front.Glowing = nbtInt(tag["GlowingText"]) != 0
+	return sign, nil
+}
+
+func signSideNBT(side SignText) string {
+	messages := make([]string, 0, 4)
+	for _, line := range paddedSignLines(side.Lines) {
+		messages = append(messages, QuoteSNBT(textComponent(line)))
+	}
+	return fmt.Sprintf("{messages:[%s],color:%q,has_glowing_text:%s}",
+		strings.Join(messages, ","), signColor(side.Color), nbtBool(side.Glowing))
+}
+
+func parseSignSide(v interface{}) SignText {
+	var side SignText
+	tag, _ := v.(nbt.Compound)
+	messages, _ := tag["messages"].(nbt.List)
+	for _, m := range messages.Items {
+		s, _ := m.(string)
+		side.Lines = append(side.Lines, plainText(s))
+	}
+	side.Color, _ = tag["color"].(string)
+	side.Glowing = nbtInt(tag["has_glowing_text"]) != 0
+	return side
+}
+
+func paddedSignLines(lines []string) []string {
+	out := make([]string, 4)
+	copy(out, lines)
+	return out
+}
+
+func signColor(color string) string {
+	if color == "" {
+		return "black"
+	}
+	return color
+}
+
+// textComponent renders plain text as a JSON text component.
+func textComponent(text string) string {
+	b, _ := json.Marshal(map[string]string{"text": text})
+	return string(b)
+}
+
+// jsonString renders s as a JSON string, which is also a valid text component.
+func jsonString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// plainText flattens a JSON text component back to its plain text.
+func plainText(component string) string {
+	var v interface{}
+	if err := json.Unmarshal([]byte(component), &v); err != nil {
+		return component
+	}
+	return flattenText(v)
+}
+
+func flattenText(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case []interface{}:
+		var b strings.Builder
+		for _, part := range t {
+			b.WriteString(flattenText(part))
+		}
+		return b.String()
+	case map[string]interface{}:
+		text, _ := t["text"].(string)
+		if extra, ok := t["extra"]; ok {
+			text += flattenText(extra)
+		}
+		return text
+	default:
+		return ""
+	}
+}
+
+func nbtBool(b bool) string {
+	if b {
+		return "1b"
+	}
+	return "0b"
+}
+
+func nbtInt(v interface{}) int64 {
+	switch n := v.(type) {
+	case int8:
+		return int64(n)
+	case int16:
+		return int64(n)
+	case int32:
+		return int64(n)
+	case int64:
+		return n
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,113 @@
+package minecraft
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// CheckSNBT does a structural check of a stringified NBT compound such as
+// `{CustomName:'"Shop"',Lock:"key"}`: it must be a single compound with
+// balanced brackets and terminated quotes. Values are checked by the server.
+func CheckSNBT(snbt string) error {
+	snbt = strings.TrimSpace(snbt)
+	if !strings.HasPrefix(snbt, "{") || !strings.HasSuffix(snbt, "}") {
+		return fmt.Errorf("NBT must be a compound wrapped in {…}")
+	}
+
+	var stack []rune
+	var quote rune
+	escaped := false
+	for i, ch := range snbt {
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case ch == '\\':
+				escaped = true
+			case ch == quote:
+				quote = 0
+			}
+			continue
+		}
+
+		switch ch {
+		case '"', '\'':
+			quote = ch
+		case '{', '[':
+			stack = append(stack, ch)
+		case '}', ']':
+			open := '{'
+			if ch == ']' {
+				open = '['
+			}
+			if len(stack) == 0 || stack[len(stack)-1] != open {
+				return fmt.Errorf("unbalanced %q at offset %d", ch, i)
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 && i != len(snbt)-1 {
+				return fmt.Errorf("unexpected data after the compound at offset %d", i+1)
+			}
+		}
+	}
+
+	if quote != 0 {
+		return fmt.Errorf("unterminated %c quote", quote)
+	}
+	if len(stack) != 0 {
+		return fmt.Errorf("unclosed %q", stack[len(stack)-1])
+	}
+	return nil
+}
+
+// MergeBlockData merges an SNBT compound into the block entity at the given
+// position (`data merge block`).
+func (c Client) MergeBlockData(ctx context.Context, nbt string, x, y, z int) error {
+	out, err := c.send(fmt.Sprintf("data merge block %d %d %d %s", x, y, z, nbt))
+	if err != nil {
+		return err
+	}
+	// "Modified block data of …", or "Nothing changed. The specified
+	// properties already have these values"
+	if !strings.Contains(out, "Modified block data") && !strings.Contains(out, "Nothing changed") {
+		return fmt.Errorf("data merge block %d %d %d: %s", x, y, z, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// GetBlockData returns the block entity NBT at the given position as SNBT.
+func (c Client) GetBlockData(ctx context.Context, x, y, z int) (string, error) {
+	out, err := c.send(fmt.Sprintf("data get block %d %d %d", x, y, z))
+	if err != nil {
+		return "", err
+	}
+
+	// Typical output:
+	// 1, 64, 1 has the following block data: {x: 1, y: 64, z: 1, id: "minecraft:chest", Items: []}
+	const marker = "has the following block data: "
+	i := strings.Index(out, marker)
+	if i < 0 {
+		return "", fmt.Errorf("unexpected response: %q", out)
+	}
+	return strings.TrimSpace(out[i+len(marker):]), nil
+}
+
+// BlockEntityData parses the output of GetBlockData into the tags that can
+// be merged back, leaving out the block entity's ID and position.
+func BlockEntityData(snbt string) (nbt.Compound, error) {
+	data, err := nbt.ParseCompound(snbt)
+	if err != nil {
+		return nil, err
+	}
+	return without(data, "id", "x", "y", "z", "keepPacked"), nil
+}
+
+// QuoteSNBT quotes s as an SNBT string using single quotes, which keeps
+// embedded JSON readable.
+func QuoteSNBT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `\'`)
+	return "'" + s + "'"
+}
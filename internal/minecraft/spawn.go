@@ -0,0 +1,166 @@
+package minecraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// ErrPlayerNotFound is returned when a player is not online, so their
+// entity data cannot be read.
+var ErrPlayerNotFound = errors.New("player is not online")
+
+// Spawn is a world spawn or a player's spawnpoint. Angle is the yaw players
+// face when they spawn.
+type Spawn struct {
+	Pos       Pos
+	Angle     float64
+	Dimension string // player spawnpoints only
+}
+
+// WorldSpawnFromLevel reads the world spawn from level.dat's Data compound.
+func WorldSpawnFromLevel(level nbt.Compound) Spawn {
+	var s Spawn
+	for key, dst := range map[string]*int{
+		"SpawnX": &s.Pos.X,
+		"SpawnY": &s.Pos.Y,
+		"SpawnZ": &s.Pos.Z,
+	} {
+		if v, ok := level[key].(int32); ok {
+			*dst = int(v)
+		}
+	}
+	if v, ok := level["SpawnAngle"].(float32); ok {
+		s.Angle = float64(v)
+	}
+	return s
+}
+
+// SetWorldSpawn moves the world spawn, where players without a spawnpoint
+// of their own appear.
+func (c Client) SetWorldSpawn(ctx context.Context, pos Pos, angle float64) error {
+	out, err := c.send(fmt.Sprintf("setworldspawn %d %d %d %s", pos.X, pos.Y, pos.Z, formatFloat(angle)))
+	if err != nil {
+		return err
+	}
+	// "Set the world spawn point to 0, 64, 0 [0.0]"
+	if !strings.Contains(out, "Set the world spawn") {
+		return fmt.Errorf("setworldspawn %s: %s", pos, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// SetSpawnpoint sets a player's spawnpoint in the client's dimension.
+func (c Client) SetSpawnpoint(ctx context.Context, player string, pos Pos, angle float64) error {
+	out, err := c.send(fmt.Sprintf("spawnpoint %s %d %d %d %s", player, pos.X, pos.Y, pos.Z, formatFloat(angle)))
+	if err != nil {
+		return err
+	}
+	// "Set spawn point to 0, 64, 0 [0.0] in minecraft:overworld for Steve"
+	if strings.Contains(out, "No player was found") {
+		return ErrPlayerNotFound
+	}
+	if !strings.Contains(out, "Set spawn point") {
+		return fmt.Errorf("spawnpoint %s %s: %s", player, pos, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// Players' spawnpoints moved from SpawnX/Y/Z, SpawnAngle and SpawnDimension
+// into a single `respawn` compound in 1.21.5.
+var respawnVersion = MustParseVersion("1.21.5")
+
+// GetSpawnpoint reads a player's spawnpoint from their entity data. It
+// returns false if the player has none, and ErrPlayerNotFound if they are
+// not online.
+func (c Client) GetSpawnpoint(ctx context.Context, version Version, player string) (Spawn, bool, error) {
+	if version.AtLeast(respawnVersion) {
+		return c.getRespawn(ctx, player)
+	}
+
+	var s Spawn
+	fields := []struct {
+		path string
+		set  func(v interface{}) bool
+	}{
+		{"SpawnX", func(v interface{}) bool { i, ok := v.(int32); s.Pos.X = int(i); return ok }},
+		{"SpawnY", func(v interface{}) bool { i, ok := v.(int32); s.Pos.Y = int(i); return ok }},
+		{"SpawnZ", func(v interface{}) bool { i, ok := v.(int32); s.Pos.Z = int(i); return ok }},
+		{"SpawnAngle", func(v interface{}) bool { f, ok := v.(float32); s.Angle = float64(f); return ok }},
+		{"SpawnDimension", func(v interface{}) bool { d, ok := v.(string); s.Dimension = d; return ok }},
+	}
+	for _, f := range fields {
+		v, ok, err := c.getEntityData(player, f.path)
+		if err != nil {
+			return Spawn{}, false, err
+		}
+		if !ok {
+			if f.path == "SpawnX" {
+				return Spawn{}, false, nil
+			}
+			continue // angle and dimension default to 0 and the overworld
+		}
+		if !f.set(v) {
+			return Spawn{}, false, fmt.Errorf("unexpected %s: %v", f.path, v)
+		}
+	}
+	if s.Dimension == "" {
+		s.Dimension = "minecraft:overworld"
+	}
+	return s, true, nil
+}
+
+// getRespawn reads the `respawn` compound, e.g.
+// {pos: [I; 8, 64, -3], angle: 90.0f, dimension: "minecraft:overworld"}.
+// From 1.21.9 the angle is stored as yaw.
+func (c Client) getRespawn(ctx context.Context, player string) (Spawn, bool, error) {
+	v, ok, err := c.getEntityData(player, "respawn")
+	if err != nil || !ok {
+		return Spawn{}, false, err
+	}
+	respawn, _ := v.(nbt.Compound)
+	pos, _ := respawn["pos"].([]int32)
+	if len(pos) != 3 {
+		return Spawn{}, false, fmt.Errorf("unexpected respawn: %v", v)
+	}
+
+	s := Spawn{Pos: Pos{int(pos[0]), int(pos[1]), int(pos[2])}, Dimension: "minecraft:overworld"}
+	for _, key := range []string{"angle", "yaw"} {
+		if f, ok := respawn[key].(float32); ok {
+			s.Angle = float64(f)
+		}
+	}
+	if d, ok := respawn["dimension"].(string); ok {
+		s.Dimension = d
+	}
+	return s, true, nil
+}
+
+// getEntityData reads one path of a player's entity data with `data get
+// entity`. It returns false if the path is not set.
+func (c Client) getEntityData(player, path string) (interface{}, bool, error) {
+	out, err := c.send(fmt.Sprintf("data get entity %s %s", player, path))
+	if err != nil {
+		return nil, false, err
+	}
+	// Typical output: Steve has the following entity data: 120
+	switch {
+	case strings.Contains(out, "No entity was found"):
+		return nil, false, ErrPlayerNotFound
+	case strings.Contains(out, "Found no elements"):
+		return nil, false, nil
+	}
+	const marker = "has the following entity data: "
+	i := strings.Index(out, marker)
+	if i < 0 {
+		return nil, false, fmt.Errorf("unexpected response: %q", out)
+	}
+	v, err := nbt.Parse(strings.TrimSpace(out[i+len(marker):]))
+	if err != nil {
+		return nil, false, err
+	}
+	return v, true, nil
+}
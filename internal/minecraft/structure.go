@@ -0,0 +1,235 @@
+package minecraft
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// `place` arrived in 1.19. Older servers load templates through a structure
+// block powered by a redstone block, and can't place anything else.
+var placeVersion = MustParseVersion("1.19")
+
+// PlaceSupported reports whether the server has `place`.
+func PlaceSupported(version Version) bool {
+	return version.AtLeast(placeVersion)
+}
+
+// Templates, features and structures are named by resource locations; the
+// namespace defaults to `minecraft`.
+var resourceLocationPattern = regexp.MustCompile(`^([a-z0-9_.-]+:)?[a-z0-9_./-]+$`)
+
+// templateRotations maps Transform rotations to the names `place template`
+// and structure block data use.
+var templateRotations = map[int][2]string{
+	0:   {"none", "NONE"},
+	90:  {"clockwise_90", "CLOCKWISE_90"},
+	180: {"180", "CLOCKWISE_180"},
+	270: {"counterclockwise_90", "COUNTERCLOCKWISE_90"},
+}
+
+// templateMirrors maps Transform mirrors the same way. The game's
+// front_back flips the X axis and left_right the Z axis.
+var templateMirrors = map[string][2]string{
+	"":     {"none", "NONE"},
+	"none": {"none", "NONE"},
+	"x":    {"front_back", "FRONT_BACK"},
+	"z":    {"left_right", "LEFT_RIGHT"},
+}
+
+// templateGroundHints are the blocks most likely to sit under a template,
+// tried first when capturing the blocks a structure block replaces.
+var templateGroundHints = []string{
+	"minecraft:grass_block",
+	"minecraft:dirt",
+	"minecraft:stone",
+	"minecraft:deepslate",
+	"minecraft:sand",
+	"minecraft:water",
+}
+
+// Template is a structure template, such as a datapack's `mypack:house` or
+// the vanilla `minecraft:igloo/top`, to be placed with its origin corner at
+// Position.
+type Template struct {
+	Name      string
+	Position  Pos
+	Transform Transform
+
+	// Integrity is the chance, from 0 to 1, that each block is placed. Seed
+	// picks which blocks are left out when it is below 1.
+	Integrity float64
+	Seed      int64
+}
+
+// Validate checks the name, transform and integrity.
+func (t Template) Validate() error {
+	if !resourceLocationPattern.MatchString(t.Name) {
+		return fmt.Errorf("template must be a resource location such as `mypack:house` (got %q)", t.Name)
+	}
+	if err := t.Transform.Validate(); err != nil {
+		return err
+	}
+	if t.Integrity < 0 || t.Integrity > 1 {
+		return fmt.Errorf("integrity must be between 0 and 1 (got %g)", t.Integrity)
+	}
+	return nil
+}
+
+// Box returns the area a template of the given size covers once placed.
+// Templates turn around their origin corner rather than their centre, so a
+// rotated or mirrored template reaches west or north of Position.
+func (t Template) Box(size Pos) Box {
+	far := t.turn(Pos{size.X - 1, size.Y - 1, size.Z - 1})
+	return NewBox(t.Position, t.Position.add(far))
+}
+
+// turn mirrors then rotates an offset around the origin, as the game does.
+func (t Template) turn(p Pos) Pos {
+	switch t.Transform.Mirror {
+	case "x":
+		p.X = -p.X
+	case "z":
+		p.Z = -p.Z
+	}
+	switch t.Transform.Rotation {
+	case 90:
+		return Pos{-p.Z, p.Y, p.X}
+	case 180:
+		return Pos{-p.X, p.Y, -p.Z}
+	case 270:
+		return Pos{p.Z, p.Y, -p.X}
+	}
+	return p
+}
+
+// PlaceTemplate places the template, with `place template` where the server
+// has it and a structure block otherwise.
+func (c Client) PlaceTemplate(ctx context.Context, version Version, registry BlockStates, t Template) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	if !PlaceSupported(version) {
+		data, err := c.loadTemplate(ctx, registry, t, false)
+		if err != nil {
+			return err
+		}
+		if templateSize(data) == (Pos{}) {
+			return fmt.Errorf("no template named %q", t.Name)
+		}
+		return nil
+	}
+
+	out, err := c.send(fmt.Sprintf("place template %s %d %d %d %s %s %s %d",
+		t.Name, t.Position.X, t.Position.Y, t.Position.Z,
+		templateRotations[t.Transform.Rotation][0], templateMirrors[t.Transform.Mirror][0],
+		strconv.FormatFloat(t.Integrity, 'g', -1, 32), t.Seed))
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(out, "Loaded template") {
+		return fmt.Errorf("place template %s: %s", t.Name, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// TemplateSize returns the size of the template before any transform. It
+// loads the template with a structure block at an integrity of 0, which
+// updates the block's size but places no blocks and no entities.
+func (c Client) TemplateSize(ctx context.Context, registry BlockStates, t Template) (Pos, error) {
+	t.Transform = Transform{}
+	t.Integrity, t.Seed = 0, 0
+	if err := t.Validate(); err != nil {
+		return Pos{}, err
+	}
+
+	data, err := c.loadTemplate(ctx, registry, t, true)
+	if err != nil {
+		return Pos{}, err
+	}
+	size := templateSize(data)
+	if size == (Pos{}) {
+		return Pos{}, fmt.Errorf("no template named %q", t.Name)
+	}
+	return size, nil
+}
+
+// loadTemplate sets a structure block in load mode one block below
+// Position, aimed at Position, and powers it with a redstone block under
+// it. It returns the structure block's data after loading, then puts back
+// the two blocks it replaced.
+func (c Client) loadTemplate(ctx context.Context, registry BlockStates, t Template, ignoreEntities bool) (nbt.Compound, error) {
+	structureBlock := Pos{t.Position.X, t.Position.Y - 1, t.Position.Z}
+	redstone := Pos{t.Position.X, t.Position.Y - 2, t.Position.Z}
+
+	var snapshots [2]BlockSnapshot
+	for i, p := range []Pos{structureBlock, redstone} {
+		snapshot, err := c.CaptureBlock(ctx, registry, templateGroundHints, p.X, p.Y, p.Z)
+		if err != nil {
+			return nil, fmt.Errorf("capture block at %s: %w", p, err)
+		}
+		snapshots[i] = snapshot
+	}
+
+	ignore := int8(0)
+	if ignoreEntities {
+		ignore = 1
+	}
+	settings := nbt.Compound{
+		"mode":            "LOAD",
+		"name":            t.Name,
+		"posX":            int32(0),
+		"posY":            int32(1),
+		"posZ":            int32(0),
+		"rotation":        templateRotations[t.Transform.Rotation][1],
+		"mirror":          templateMirrors[t.Transform.Mirror][1],
+		"integrity":       float32(t.Integrity),
+		"seed":            t.Seed,
+		"ignoreEntities":  ignore,
+		"showboundingbox": int8(0),
+	}
+
+	data, err := func() (nbt.Compound, error) {
+		block := "minecraft:structure_block[mode=load]" + nbt.Stringify(settings)
+		if err := c.CreateBlock(ctx, block, structureBlock.X, structureBlock.Y, structureBlock.Z); err != nil {
+			return nil, err
+		}
+		// The neighbour update from the redstone block triggers the load.
+		if err := c.CreateBlock(ctx, "minecraft:redstone_block", redstone.X, redstone.Y, redstone.Z); err != nil {
+			return nil, err
+		}
+		snbt, err := c.GetBlockData(ctx, structureBlock.X, structureBlock.Y, structureBlock.Z)
+		if err != nil {
+			return nil, err
+		}
+		return nbt.ParseCompound(snbt)
+	}()
+
+	// Unpower the structure block before replacing it.
+	if rerr := c.Restore(ctx, snapshots[1], redstone.X, redstone.Y, redstone.Z); rerr != nil && err == nil {
+		err = rerr
+	}
+	if rerr := c.Restore(ctx, snapshots[0], structureBlock.X, structureBlock.Y, structureBlock.Z); rerr != nil && err == nil {
+		err = rerr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load template %s with a structure block: %w", t.Name, err)
+	}
+	return data, nil
+}
+
+// templateSize reads the size a structure block reports after loading. It
+// stays 0,0,0 when the template does not exist.
+func templateSize(data nbt.Compound) Pos {
+	var size [3]int
+	for i, key := range []string{"sizeX", "sizeY", "sizeZ"} {
+		n, _ := data[key].(int32)
+		size[i] = int(n)
+	}
+	return Pos{size[0], size[1], size[2]}
+}
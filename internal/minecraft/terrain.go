@@ -0,0 +1,272 @@
+package minecraft
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"sort"
+)
+
+// maxTerrainArea caps the columns a terrain may cover.
+const maxTerrainArea = 1 << 20
+
+// TerrainLayer is a band of one block below the surface. Layers are listed
+// top down; the last one fills the rest of the column.
+type TerrainLayer struct {
+	Block string
+	Depth int
+}
+
+// DefaultTerrainLayers is grass over three blocks of dirt over stone.
+var DefaultTerrainLayers = []TerrainLayer{
+	{Block: "minecraft:grass_block", Depth: 1},
+	{Block: "minecraft:dirt", Depth: 3},
+	{Block: "minecraft:stone"},
+}
+
+// Terrain describes a landscape raised from a heightmap. Origin is the
+// north-west corner at the base Y; the heightmap is stretched over Width
+// blocks along X and Length blocks along Z with north at the top. Black
+// pixels are one block thick and white pixels reach MaxHeight blocks above
+// the base.
+type Terrain struct {
+	Origin    Pos
+	Width     int
+	Length    int
+	MaxHeight int
+	Layers    []TerrainLayer
+	Water     bool // fill columns below SeaLevel with water
+	SeaLevel  int
+}
+
+// Segment is a vertical run of one block from Y From to To inclusive.
+type Segment struct {
+	From, To int
+	Block    string
+}
+
+// Column is the stack of segments at one X/Z, bottom up.
+type Column []Segment
+
+// Top returns the highest Y the column fills.
+func (c Column) Top() int {
+	return c[len(c)-1].To
+}
+
+// Equal reports whether two columns hold the same blocks.
+func (c Column) Equal(o Column) bool {
+	if len(c) != len(o) {
+		return false
+	}
+	for i := range c {
+		if c[i] != o[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks the terrain dimensions and layers.
+func (t Terrain) Validate() error {
+	if t.Width < 1 || t.Length < 1 {
+		return fmt.Errorf("width and length must be at least 1")
+	}
+	if t.Width*t.Length > maxTerrainArea {
+		return fmt.Errorf("the terrain covers %d columns, more than the limit of %d", t.Width*t.Length, maxTerrainArea)
+	}
+	if t.MaxHeight < 0 {
+		return fmt.Errorf("max_height must not be negative")
+	}
+	for i, layer := range t.Layers {
+		if layer.Block == "" {
+			return fmt.Errorf("layer %d needs a material", i)
+		}
+		if layer.Depth < 0 {
+			return fmt.Errorf("layer %d: depth must not be negative", i)
+		}
+	}
+	return nil
+}
+
+// Heights samples the heightmap into the surface Y of every column,
+// indexed [z][x].
+func (t Terrain) Heights(img image.Image) [][]int {
+	pixels := Resample(img, t.Width, t.Length)
+	heights := make([][]int, t.Length)
+	for z, row := range pixels {
+		heights[z] = make([]int, t.Width)
+		for x, p := range row {
+			gray := clamp255(0.299*p.R+0.587*p.G+0.114*p.B) / 255
+			heights[z][x] = t.Origin.Y + int(math.Round(gray*float64(t.MaxHeight)))
+		}
+	}
+	return heights
+}
+
+// Column builds the column whose surface is at Y top.
+func (t Terrain) Column(top int) Column {
+	layers := t.Layers
+	if len(layers) == 0 {
+		layers = DefaultTerrainLayers
+	}
+
+	var col Column
+	y := top
+	for i, layer := range layers {
+		if y < t.Origin.Y {
+			break
+		}
+		from := t.Origin.Y
+		if i < len(layers)-1 {
+			if layer.Depth == 0 {
+				continue
+			}
+			from = maxInt(t.Origin.Y, y-layer.Depth+1)
+		}
+		col = append(Column{{From: from, To: y, Block: layer.Block}}, col...)
+		y = from - 1
+	}
+	if t.Water && top < t.SeaLevel {
+		col = append(col, Segment{From: top + 1, To: t.SeaLevel, Block: "minecraft:water"})
+	}
+	return col
+}
+
+// Columns builds every column of the terrain from the heightmap, keyed by
+// world {X, Z}.
+func (t Terrain) Columns(img image.Image) map[[2]int]Column {
+	cols := make(map[[2]int]Column, t.Width*t.Length)
+	for z, row := range t.Heights(img) {
+		for x, top := range row {
+			cols[[2]int{t.Origin.X + x, t.Origin.Z + z}] = t.Column(top)
+		}
+	}
+	return cols
+}
+
+// Footprint returns every column the terrain could have filled, each a
+// single segment of unknown ("") block from the base to the highest point
+// the heightmap or the sea could reach.
+func (t Terrain) Footprint() map[[2]int]Column {
+	top := t.Origin.Y + t.MaxHeight
+	if t.Water && t.SeaLevel > top {
+		top = t.SeaLevel
+	}
+	cols := make(map[[2]int]Column, t.Width*t.Length)
+	for z := 0; z < t.Length; z++ {
+		for x := 0; x < t.Width; x++ {
+			cols[[2]int{t.Origin.X + x, t.Origin.Z + z}] = Column{{From: t.Origin.Y, To: top}}
+		}
+	}
+	return cols
+}
+
+// CoalesceColumns merges the same segment in neighbouring columns into
+// boxes, growing runs along X then Z, with no box over MaxFillVolume.
+// Boxes of air come after solid blocks, and water comes last, so water
+// never flows into a gap that is about to be filled.
+func CoalesceColumns(cols map[[2]int]Column) []Box {
+	cells := map[Segment]map[[2]int]bool{}
+	for xz, col := range cols {
+		for _, seg := range col {
+			if cells[seg] == nil {
+				cells[seg] = map[[2]int]bool{}
+			}
+			cells[seg][xz] = true
+		}
+	}
+
+	segments := make([]Segment, 0, len(cells))
+	for seg := range cells {
+		segments = append(segments, seg)
+	}
+	rank := func(block string) int {
+		switch block {
+		case "minecraft:air":
+			return 1
+		case "minecraft:water":
+			return 2
+		}
+		return 0
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		a, b := segments[i], segments[j]
+		if rank(a.Block) != rank(b.Block) {
+			return rank(a.Block) < rank(b.Block)
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Block < b.Block
+	})
+
+	var boxes []Box
+	for _, seg := range segments {
+		group := cells[seg]
+		height := seg.To - seg.From + 1
+		starts := make([][2]int, 0, len(group))
+		for xz := range group {
+			starts = append(starts, xz)
+		}
+		sort.Slice(starts, func(i, j int) bool {
+			if starts[i][1] != starts[j][1] {
+				return starts[i][1] < starts[j][1]
+			}
+			return starts[i][0] < starts[j][0]
+		})
+
+		done := make(map[[2]int]bool, len(group))
+		free := func(x, z int) bool {
+			xz := [2]int{x, z}
+			return group[xz] && !done[xz]
+		}
+		for _, start := range starts {
+			if done[start] {
+				continue
+			}
+			maxX, maxZ := start[0], start[1]
+			for free(maxX+1, start[1]) && (maxX-start[0]+2)*height <= MaxFillVolume {
+				maxX++
+			}
+			width := maxX - start[0] + 1
+
+		growZ:
+			for (maxZ-start[1]+2)*width*height <= MaxFillVolume {
+				for x := start[0]; x <= maxX; x++ {
+					if !free(x, maxZ+1) {
+						break growZ
+					}
+				}
+				maxZ++
+			}
+
+			for z := start[1]; z <= maxZ; z++ {
+				for x := start[0]; x <= maxX; x++ {
+					done[[2]int{x, z}] = true
+				}
+			}
+			boxes = append(boxes, Box{
+				Min:   Pos{start[0], seg.From, start[1]},
+				Max:   Pos{maxX, seg.To, maxZ},
+				Block: seg.Block,
+			})
+		}
+	}
+	return boxes
+}
+
+// SetColumns writes the columns using as few commands as CoalesceColumns
+// allows and returns the number of commands sent.
+func (c Client) SetColumns(ctx context.Context, cols map[[2]int]Column) (int, error) {
+	boxes := CoalesceColumns(cols)
+	for i, box := range boxes {
+		if err := c.FillBox(ctx, box); err != nil {
+			return i, err
+		}
+	}
+	return len(boxes), nil
+}
@@ -0,0 +1,175 @@
+package minecraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// BlockSnapshot is a block as it was before a change: its full block state,
+// e.g. `minecraft:chest[facing=north,type=single,waterlogged=false]`, and its
+// block entity data if it has any.
+type BlockSnapshot struct {
+	Block string
+	NBT   string
+}
+
+// CaptureBlock identifies the block at the given position so it can be put
+// back later. Air is checked first, then the materials in hints, then every
+// block in the registry; the last step is slow, so callers should pass the
+// blocks they expect to find. Blocks outside the registry yield an error
+// wrapping ErrUnknownBlock.
+func (c Client) CaptureBlock(ctx context.Context, registry BlockStates, hints []string, x, y, z int) (BlockSnapshot, error) {
+	candidates := []string{"minecraft:air", "minecraft:cave_air", "minecraft:void_air"}
+	for _, hint := range hints {
+		if i := strings.Index(hint, "["); i >= 0 {
+			hint = hint[:i]
+		}
+		if i := strings.Index(hint, "{"); i >= 0 {
+			hint = hint[:i]
+		}
+		candidates = append(candidates, NormalizeMaterial(hint))
+	}
+	candidates = append(candidates, registry.IDs()...)
+
+	seen := map[string]bool{}
+	for _, material := range candidates {
+		if seen[material] {
+			continue
+		}
+		seen[material] = true
+
+		ok, err := c.TestBlock(ctx, material, x, y, z)
+		if err != nil {
+			return BlockSnapshot{}, err
+		}
+		if !ok {
+			continue
+		}
+
+		state, err := c.GetBlockState(ctx, material, registry[material], x, y, z)
+		if err != nil {
+			return BlockSnapshot{}, err
+		}
+		snapshot := BlockSnapshot{Block: BlockWithState(material, state)}
+		// Blocks without a block entity make `data get block` fail; that
+		// just means there is no data to keep.
+		if nbt, err := c.GetBlockData(ctx, x, y, z); err == nil {
+			snapshot.NBT = nbt
+		}
+		return snapshot, nil
+	}
+	return BlockSnapshot{}, fmt.Errorf("%w: unable to identify the block at %d %d %d", ErrUnknownBlock, x, y, z)
+}
+
+// Restore puts a captured block back at the given position.
+func (c Client) Restore(ctx context.Context, snapshot BlockSnapshot, x, y, z int) error {
+	return c.CreateBlock(ctx, snapshot.Block+snapshot.NBT, x, y, z)
+}
+
+// Transaction groups block changes so they apply all-or-nothing. Every change
+// first captures the block it is about to replace; Rollback puts the captured
+// blocks back in reverse order.
+type Transaction struct {
+	client   Client
+	registry BlockStates
+	undo     []undoStep
+	touched  map[[3]int]bool
+}
+
+type undoStep struct {
+	x, y, z  int
+	snapshot BlockSnapshot
+	err      error // the block could not be captured
+}
+
+// Begin starts a transaction. The registry is used to capture prior blocks.
+func (c Client) Begin(registry BlockStates) *Transaction {
+	return &Transaction{client: c, registry: registry, touched: map[[3]int]bool{}}
+}
+
+// Transact runs fn in a transaction and rolls it back if fn fails. The
+// returned error is fn's, extended with any rollback failure.
+func (c Client) Transact(ctx context.Context, registry BlockStates, fn func(tx *Transaction) error) error {
+	tx := c.Begin(registry)
+	err := fn(tx)
+	if err == nil {
+		return nil
+	}
+	if rerr := tx.Rollback(ctx); rerr != nil {
+		return fmt.Errorf("%w; rollback failed: %s", err, rerr)
+	}
+	return err
+}
+
+// capture records the block at the position the first time the transaction
+// touches it. Later changes to the same position roll back to that block.
+func (t *Transaction) capture(ctx context.Context, hint string, x, y, z int) error {
+	key := [3]int{x, y, z}
+	if t.touched[key] {
+		return nil
+	}
+
+	var hints []string
+	if hint != "" {
+		hints = []string{hint}
+	}
+	snapshot, err := t.client.CaptureBlock(ctx, t.registry, hints, x, y, z)
+	if err != nil && !errors.Is(err, ErrUnknownBlock) {
+		return err
+	}
+	t.touched[key] = true
+	t.undo = append(t.undo, undoStep{x: x, y: y, z: z, snapshot: snapshot, err: err})
+	return nil
+}
+
+// SetBlock places a block (`setblock … replace`) after capturing the one it
+// replaces. The hint is the material expected there now, or "" when it
+// should be air.
+func (t *Transaction) SetBlock(ctx context.Context, hint, block string, x, y, z int) error {
+	if err := t.capture(ctx, hint, x, y, z); err != nil {
+		return err
+	}
+	return t.client.CreateBlock(ctx, block, x, y, z)
+}
+
+// DeleteBlock replaces a block with air after capturing it.
+func (t *Transaction) DeleteBlock(ctx context.Context, hint string, x, y, z int) error {
+	if err := t.capture(ctx, hint, x, y, z); err != nil {
+		return err
+	}
+	return t.client.DeleteBlock(ctx, x, y, z)
+}
+
+// MergeBlockData merges block entity data after capturing the block.
+func (t *Transaction) MergeBlockData(ctx context.Context, hint, nbt string, x, y, z int) error {
+	if err := t.capture(ctx, hint, x, y, z); err != nil {
+		return err
+	}
+	return t.client.MergeBlockData(ctx, nbt, x, y, z)
+}
+
+// Rollback restores every captured block, newest first. Blocks that could not
+// be captured are cleared to air and reported in the error.
+func (t *Transaction) Rollback(ctx context.Context) error {
+	var failed []string
+	for i := len(t.undo) - 1; i >= 0; i-- {
+		step := t.undo[i]
+		if step.err != nil {
+			_ = t.client.DeleteBlock(ctx, step.x, step.y, step.z)
+			failed = append(failed, fmt.Sprintf("%d %d %d: %s", step.x, step.y, step.z, step.err))
+			continue
+		}
+		if err := t.client.Restore(ctx, step.snapshot, step.x, step.y, step.z); err != nil {
+			failed = append(failed, fmt.Sprintf("%d %d %d: %s", step.x, step.y, step.z, err))
+		}
+	}
+	t.undo = nil
+	t.touched = map[[3]int]bool{}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("unable to restore %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package minecraft
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Mirrors are the axes a Transform can flip along.
+var Mirrors = []string{"none", "x", "z"}
+
+// Transform turns a build around the Y axis and mirrors it. Mirroring is
+// applied first, so `Mirror: "x"` always flips the original east-west axis.
+type Transform struct {
+	Rotation int    // degrees clockwise seen from above: 0, 90, 180 or 270
+	Mirror   string // "", "none", "x" (flip east-west) or "z" (flip north-south)
+}
+
+// Validate checks the rotation and mirror are supported.
+func (t Transform) Validate() error {
+	switch t.Rotation {
+	case 0, 90, 180, 270:
+	default:
+		return fmt.Errorf("rotation must be one of 0, 90, 180, 270 (got %d)", t.Rotation)
+	}
+	switch t.Mirror {
+	case "", "none", "x", "z":
+	default:
+		return fmt.Errorf("mirror must be one of none, x, z (got %q)", t.Mirror)
+	}
+	return nil
+}
+
+// Size returns the size of a box of the given size once transformed.
+func (t Transform) Size(size Pos) Pos {
+	if t.Rotation == 90 || t.Rotation == 270 {
+		return Pos{size.Z, size.Y, size.X}
+	}
+	return size
+}
+
+// Apply maps p, an offset inside a box of the given size, to its offset in
+// the transformed box. Both boxes start at 0,0,0.
+func (t Transform) Apply(p, size Pos) Pos {
+	switch t.Mirror {
+	case "x":
+		p.X = size.X - 1 - p.X
+	case "z":
+		p.Z = size.Z - 1 - p.Z
+	}
+	switch t.Rotation {
+	case 90:
+		return Pos{size.Z - 1 - p.Z, p.Y, p.X}
+	case 180:
+		return Pos{size.X - 1 - p.X, p.Y, size.Z - 1 - p.Z}
+	case 270:
+		return Pos{p.Z, p.Y, size.X - 1 - p.X}
+	}
+	return p
+}
+
+// Facing returns where a horizontal direction (north, east, south or west)
+// ends up after the transform. Anything else, such as up, is returned as is.
+func (t Transform) Facing(facing string) string {
+	switch {
+	case t.Mirror == "x" && (facing == "east" || facing == "west"),
+		t.Mirror == "z" && (facing == "north" || facing == "south"):
+		facing = clockwise[clockwise[facing]]
+	}
+	for i := 0; i < t.Rotation/90; i++ {
+		if next, ok := clockwise[facing]; ok {
+			facing = next
+		}
+	}
+	return facing
+}
+
+// clockwise maps each horizontal direction to the one 90 degrees clockwise
+// seen from above.
+var clockwise = map[string]string{
+	"north": "east",
+	"east":  "south",
+	"south": "west",
+	"west":  "north",
+}
+
+// Block turns the directional properties of a block state, such as
+// `facing`, `axis`, `rotation` and the `north`/`east`/… connections of
+// fences, along with the transform.
+func (t Transform) Block(block string) string {
+	id, state := ParseBlock(block)
+	if len(state) == 0 || (t.Rotation == 0 && (t.Mirror == "" || t.Mirror == "none")) {
+		return block
+	}
+
+	mirrored := t.Mirror == "x" || t.Mirror == "z"
+	out := make(map[string]string, len(state))
+	for k, v := range state {
+		switch k {
+		case "facing":
+			v = t.Facing(v)
+		case "axis":
+			if t.Rotation == 90 || t.Rotation == 270 {
+				switch v {
+				case "x":
+					v = "z"
+				case "z":
+					v = "x"
+				}
+			}
+		case "rotation":
+			if r, err := strconv.Atoi(v); err == nil {
+				switch t.Mirror {
+				case "x":
+					r = 16 - r
+				case "z":
+					r = 8 - r
+				}
+				v = strconv.Itoa(((r+t.Rotation/90*4)%16 + 16) % 16)
+			}
+		case "north", "east", "south", "west":
+			k = t.Facing(k)
+		case "shape":
+			v = t.shape(v)
+		case "hinge", "type":
+			// Door hinges and double chest halves swap sides in a mirror.
+			if mirrored {
+				switch v {
+				case "left":
+					v = "right"
+				case "right":
+					v = "left"
+				}
+			}
+		case "orientation":
+			// Jigsaws and crafters: "<front>_<top>", e.g. "north_up".
+			if parts := strings.SplitN(v, "_", 2); len(parts) == 2 {
+				v = t.Facing(parts[0]) + "_" + t.Facing(parts[1])
+			}
+		}
+		out[k] = v
+	}
+	return BlockWithState(id, out)
+}
+
+// shape turns a rail or stair shape.
+func (t Transform) shape(v string) string {
+	switch {
+	case strings.HasSuffix(v, "_left") || strings.HasSuffix(v, "_right"):
+		// Stairs: inner_left, outer_right, ...
+		if t.Mirror == "x" || t.Mirror == "z" {
+			if strings.HasSuffix(v, "_left") {
+				return strings.TrimSuffix(v, "_left") + "_right"
+			}
+			return strings.TrimSuffix(v, "_right") + "_left"
+		}
+		return v
+	case strings.HasPrefix(v, "ascending_"):
+		return "ascending_" + t.Facing(strings.TrimPrefix(v, "ascending_"))
+	}
+
+	parts := strings.Split(v, "_")
+	if len(parts) != 2 {
+		return v
+	}
+	a, b := t.Facing(parts[0]), t.Facing(parts[1])
+	switch {
+	case (a == "east" || a == "west") && (b == "east" || b == "west"):
+		return "east_west"
+	case (a == "north" || a == "south") && (b == "north" || b == "south"):
+		return "north_south"
+	case a == "east" || a == "west":
+		// Curved rails name north or south first.
+		a, b = b, a
+	}
+	return a + "_" + b
+}
+
+// Point maps a point inside a box of the given size, such as an entity
+// position, the same way Apply maps blocks.
+func (t Transform) Point(p [3]float64, size Pos) [3]float64 {
+	sx, sz := float64(size.X), float64(size.Z)
+	switch t.Mirror {
+	case "x":
+		p[0] = sx - p[0]
+	case "z":
+		p[2] = sz - p[2]
+	}
+	switch t.Rotation {
+	case 90:
+		return [3]float64{sz - p[2], p[1], p[0]}
+	case 180:
+		return [3]float64{sx - p[0], p[1], sz - p[2]}
+	case 270:
+		return [3]float64{p[2], p[1], sx - p[0]}
+	}
+	return p
+}
+
+// Yaw turns an entity's yaw (degrees, 0 facing south, increasing
+// clockwise seen from above).
+func (t Transform) Yaw(yaw float32) float32 {
+	switch t.Mirror {
+	case "x":
+		yaw = -yaw
+	case "z":
+		yaw = 180 - yaw
+	}
+	yaw = float32(math.Mod(float64(yaw)+float64(t.Rotation), 360))
+	if yaw < 0 {
+		yaw += 360
+	}
+	return yaw
+}
@@ -0,0 +1,67 @@
+package minecraft
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LatestVersion is the newest Java Edition release the embedded data covers.
+// It is assumed when the provider is not told which server version it talks to.
+const LatestVersion = "1.21.4"
+
+// Version is a Java Edition release number such as 1.20.2.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses "1.20" or "1.20.2". An empty string means LatestVersion.
+func ParseVersion(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		s = LatestVersion
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q, expected e.g. 1.20 or 1.20.2", s)
+	}
+
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q, expected e.g. 1.20 or 1.20.2", s)
+		}
+		nums[i] = n
+	}
+
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+// MustParseVersion is ParseVersion for version literals known to be valid.
+func MustParseVersion(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// AtLeast reports whether v is the same release as o or a later one.
+func (v Version) AtLeast(o Version) bool {
+	if v.Major != o.Major {
+		return v.Major > o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor > o.Minor
+	}
+	return v.Patch >= o.Patch
+}
+
+func (v Version) String() string {
+	if v.Patch == 0 {
+		return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
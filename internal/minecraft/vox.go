@@ -0,0 +1,364 @@
+package minecraft
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image/color"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// VoxelModel is a MagicaVoxel model converted to Minecraft axes: Y is up and
+// the model's front faces south. Voxels are offset so the smallest
+// coordinate on every axis is 0.
+type VoxelModel struct {
+	Size    Pos
+	Voxels  map[Pos]uint8 // palette index, 1-255
+	Palette [256]color.RGBA
+}
+
+// ReadVox parses a MagicaVoxel .vox file. Files with several models are
+// assembled using the translations and rotations of their scene graph.
+func ReadVox(path string) (*VoxelModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m, err := ParseVox(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return m, nil
+}
+
+// voxReader reads little-endian values from a .vox file and remembers the
+// first error, so callers can check once after a run of reads.
+type voxReader struct {
+	data []byte
+	off  int
+	err  error
+}
+
+func (r *voxReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.off+n > len(r.data) {
+		r.err = fmt.Errorf("unexpected end of file at byte %d", r.off)
+		return nil
+	}
+	b := r.data[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+func (r *voxReader) int32() int {
+	b := r.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return int(int32(binary.LittleEndian.Uint32(b)))
+}
+
+func (r *voxReader) string() string {
+	return string(r.bytes(r.int32()))
+}
+
+func (r *voxReader) dict() map[string]string {
+	n := r.int32()
+	d := map[string]string{}
+	for i := 0; i < n && r.err == nil; i++ {
+		k := r.string()
+		d[k] = r.string()
+	}
+	return d
+}
+
+// voxAffine is a scene graph transform: a signed permutation matrix and a
+// translation.
+type voxAffine struct {
+	m [3][3]int
+	t [3]int
+}
+
+var voxIdentity = voxAffine{m: [3][3]int{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}}
+
+func (a voxAffine) apply(v [3]int) [3]int {
+	var out [3]int
+	for i := 0; i < 3; i++ {
+		out[i] = a.m[i][0]*v[0] + a.m[i][1]*v[1] + a.m[i][2]*v[2] + a.t[i]
+	}
+	return out
+}
+
+// then returns the transform that applies b, then a.
+func (a voxAffine) then(b voxAffine) voxAffine {
+	var out voxAffine
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			out.m[i][j] = a.m[i][0]*b.m[0][j] + a.m[i][1]*b.m[1][j] + a.m[i][2]*b.m[2][j]
+		}
+	}
+	out.t = a.apply(b.t)
+	return out
+}
+
+// voxRotation decodes the packed rotation byte of a transform node: bits 0-1
+// and 2-3 give the column of the non-zero entry in the first and second
+// rows, and bits 4-6 the sign of each row.
+func voxRotation(b int) ([3][3]int, error) {
+	c0, c1 := b&3, (b>>2)&3
+	if c0 > 2 || c1 > 2 || c0 == c1 {
+		return [3][3]int{}, fmt.Errorf("invalid rotation %d", b)
+	}
+	cols := [3]int{c0, c1, 3 - c0 - c1}
+	var m [3][3]int
+	for row, col := range cols {
+		m[row][col] = 1
+		if b&(1<<(4+row)) != 0 {
+			m[row][col] = -1
+		}
+	}
+	return m, nil
+}
+
+type voxNode struct {
+	kind     string // nTRN, nGRP or nSHP
+	children []int  // child node for nTRN and nGRP, model for nSHP
+	affine   voxAffine
+}
+
+type voxShape struct {
+	size   [3]int
+	voxels [][4]byte
+}
+
+// ParseVox parses the contents of a MagicaVoxel .vox file.
+func ParseVox(data []byte) (*VoxelModel, error) {
+	r := &voxReader{data: data}
+	if string(r.bytes(4)) != "VOX " {
+		return nil, fmt.Errorf("not a .vox file")
+	}
+	r.int32() // version
+	if string(r.bytes(4)) != "MAIN" {
+		return nil, fmt.Errorf("missing MAIN chunk")
+	}
+	r.bytes(r.int32()) // MAIN has no content of its own
+	end := r.off + r.int32()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var shapes []voxShape
+	var palette *[256]color.RGBA
+	nodes := map[int]voxNode{}
+
+	for r.off < end && r.err == nil {
+		id := string(r.bytes(4))
+		size := r.int32()
+		children := r.int32()
+		chunk := &voxReader{data: r.bytes(size)}
+		r.bytes(children)
+		if r.err != nil {
+			break
+		}
+
+		switch id {
+		case "SIZE":
+			shapes = append(shapes, voxShape{size: [3]int{chunk.int32(), chunk.int32(), chunk.int32()}})
+		case "XYZI":
+			if len(shapes) == 0 {
+				return nil, fmt.Errorf("XYZI chunk before SIZE")
+			}
+			n := chunk.int32()
+			s := &shapes[len(shapes)-1]
+			for i := 0; i < n && chunk.err == nil; i++ {
+				var v [4]byte
+				copy(v[:], chunk.bytes(4))
+				s.voxels = append(s.voxels, v)
+			}
+		case "RGBA":
+			palette = &[256]color.RGBA{}
+			for i := 0; i < 255; i++ {
+				b := chunk.bytes(4)
+				if b != nil {
+					palette[i+1] = color.RGBA{b[0], b[1], b[2], b[3]}
+				}
+			}
+		case "nTRN":
+			nodeID := chunk.int32()
+			chunk.dict()
+			node := voxNode{kind: id, children: []int{chunk.int32()}, affine: voxIdentity}
+			chunk.int32() // reserved
+			chunk.int32() // layer
+			if chunk.int32() > 0 {
+				frame := chunk.dict()
+				if t, ok := frame["_t"]; ok {
+					fields := strings.Fields(t)
+					for i := 0; i < 3 && i < len(fields); i++ {
+						node.affine.t[i], _ = strconv.Atoi(fields[i])
+					}
+				}
+				if rot, ok := frame["_r"]; ok {
+					b, _ := strconv.Atoi(rot)
+					m, err := voxRotation(b)
+					if err != nil {
+						return nil, err
+					}
+					node.affine.m = m
+				}
+			}
+			nodes[nodeID] = node
+		case "nGRP":
+			nodeID := chunk.int32()
+			chunk.dict()
+			node := voxNode{kind: id}
+			n := chunk.int32()
+			for i := 0; i < n && chunk.err == nil; i++ {
+				node.children = append(node.children, chunk.int32())
+			}
+			nodes[nodeID] = node
+		case "nSHP":
+			nodeID := chunk.int32()
+			chunk.dict()
+			node := voxNode{kind: id}
+			n := chunk.int32()
+			for i := 0; i < n && chunk.err == nil; i++ {
+				node.children = append(node.children, chunk.int32())
+				chunk.dict()
+			}
+			nodes[nodeID] = node
+		}
+		if chunk.err != nil {
+			return nil, fmt.Errorf("%s chunk: %w", id, chunk.err)
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(shapes) == 0 {
+		return nil, fmt.Errorf("no models")
+	}
+
+	// Collect voxels in MagicaVoxel coordinates (Z up).
+	raw := map[[3]int]uint8{}
+	add := func(s voxShape, a voxAffine, centre bool) {
+		for _, v := range s.voxels {
+			p := [3]int{int(v[0]), int(v[1]), int(v[2])}
+			if centre {
+				// Scene graph models are positioned by their centre.
+				for i := range p {
+					p[i] -= s.size[i] / 2
+				}
+			}
+			raw[a.apply(p)] = v[3]
+		}
+	}
+
+	if _, ok := nodes[0]; !ok {
+		add(shapes[0], voxIdentity, false)
+	} else {
+		var walk func(id int, a voxAffine, depth int) error
+		walk = func(id int, a voxAffine, depth int) error {
+			node, ok := nodes[id]
+			if !ok {
+				return fmt.Errorf("scene graph refers to missing node %d", id)
+			}
+			if depth > 64 {
+				return fmt.Errorf("scene graph is too deep")
+			}
+			switch node.kind {
+			case "nTRN":
+				return walk(node.children[0], a.then(node.affine), depth+1)
+			case "nGRP":
+				for _, child := range node.children {
+					if err := walk(child, a, depth+1); err != nil {
+						return err
+					}
+				}
+			case "nSHP":
+				for _, model := range node.children {
+					if model < 0 || model >= len(shapes) {
+						return fmt.Errorf("scene graph refers to missing model %d", model)
+					}
+					add(shapes[model], a, true)
+				}
+			}
+			return nil
+		}
+		if err := walk(0, voxIdentity, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	model := &VoxelModel{Voxels: make(map[Pos]uint8, len(raw))}
+	if palette != nil {
+		model.Palette = *palette
+	} else {
+		model.Palette = defaultVoxPalette()
+	}
+	if len(raw) == 0 {
+		return model, nil
+	}
+
+	// MagicaVoxel is Z up with Y pointing away from the viewer; turn that
+	// into Minecraft's Y up, Z south, keeping the model's handedness.
+	first := true
+	var min, max Pos
+	for v := range raw {
+		p := Pos{X: v[0], Y: v[2], Z: -v[1]}
+		if first {
+			min, max, first = p, p, false
+		}
+		min = Pos{minInt(min.X, p.X), minInt(min.Y, p.Y), minInt(min.Z, p.Z)}
+		max = Pos{maxInt(max.X, p.X), maxInt(max.Y, p.Y), maxInt(max.Z, p.Z)}
+	}
+	for v, index := range raw {
+		model.Voxels[Pos{X: v[0] - min.X, Y: v[2] - min.Y, Z: -v[1] - min.Z}] = index
+	}
+	model.Size = Pos{max.X - min.X + 1, max.Y - min.Y + 1, max.Z - min.Z + 1}
+	return model, nil
+}
+
+// defaultVoxPalette is the palette MagicaVoxel uses for files without an
+// RGBA chunk: index 0 is empty, then a 6x6x6 colour cube without black,
+// then ramps of red, green, blue and gray.
+func defaultVoxPalette() [256]color.RGBA {
+	var p [256]color.RGBA
+	p[0] = color.RGBA{} // no voxel
+	i := 1
+	steps := []uint8{0xff, 0xcc, 0x99, 0x66, 0x33, 0x00}
+	for _, r := range steps {
+		for _, g := range steps {
+			for _, b := range steps {
+				if r == 0 && g == 0 && b == 0 {
+					continue
+				}
+				p[i] = color.RGBA{R: r, G: g, B: b, A: 0xff}
+				i++
+			}
+		}
+	}
+	ramp := []uint8{0xee, 0xdd, 0xbb, 0xaa, 0x88, 0x77, 0x55, 0x44, 0x22, 0x11}
+	for _, channel := range []int{0, 1, 2} {
+		for _, v := range ramp {
+			c := color.RGBA{A: 0xff}
+			switch channel {
+			case 0:
+				c.R = v
+			case 1:
+				c.G = v
+			case 2:
+				c.B = v
+			}
+			p[i] = c
+			i++
+		}
+	}
+	for _, v := range ramp {
+		p[i] = color.RGBA{v, v, v, 0xff}
+		i++
+	}
+	return p
+}
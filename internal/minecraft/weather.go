@@ -0,0 +1,113 @@
+package minecraft
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+var (
+	// From 1.19.4 a bare `weather` duration is in ticks; a unit suffix is
+	// needed for seconds.
+	weatherUnitsVersion = MustParseVersion("1.19.4")
+	// Inline predicates, used to query the weather over RCON, arrived in
+	// 1.20.5.
+	inlinePredicateVersion = MustParseVersion("1.20.5")
+)
+
+// Weathers are the values `weather` accepts.
+var Weathers = []string{"clear", "rain", "thunder"}
+
+// ValidateWeather checks that weather is one of Weathers.
+func ValidateWeather(weather string) error {
+	for _, w := range Weathers {
+		if weather == w {
+			return nil
+		}
+	}
+	return fmt.Errorf("weather must be one of %s (got %q)", strings.Join(Weathers, ", "), weather)
+}
+
+// WeatherQuerySupported reports whether GetWeather works on the server.
+func WeatherQuerySupported(version Version) bool {
+	return version.AtLeast(inlinePredicateVersion)
+}
+
+// WeatherFromLevel reads the weather from level.dat's Data compound.
+// Thunder without rain looks clear in game, so it counts as clear.
+func WeatherFromLevel(level nbt.Compound) string {
+	raining, _ := level["raining"].(int8)
+	thundering, _ := level["thundering"].(int8)
+	switch {
+	case raining != 0 && thundering != 0:
+		return "thunder"
+	case raining != 0:
+		return "rain"
+	}
+	return "clear"
+}
+
+// SetWeather changes the weather for the given number of seconds, or for a
+// random time when that is zero. The weather cycle must be on for it to
+// change again afterwards.
+func (c Client) SetWeather(ctx context.Context, version Version, weather string, seconds int) error {
+	if err := ValidateWeather(weather); err != nil {
+		return err
+	}
+	command := "weather " + weather
+	if seconds > 0 {
+		command += fmt.Sprintf(" %d", seconds)
+		if version.AtLeast(weatherUnitsVersion) {
+			command += "s"
+		}
+	}
+	out, err := c.send(command)
+	if err != nil {
+		return err
+	}
+	// "Set the weather to clear" / "Changing to rain"
+	if !strings.Contains(out, "weather") && !strings.Contains(out, "Changing") {
+		return fmt.Errorf("weather %s: %s", weather, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// GetWeather returns the current weather, tested with `weather_check`
+// predicates. It needs Minecraft 1.20.5 or later.
+func (c Client) GetWeather(ctx context.Context, version Version) (string, error) {
+	if !WeatherQuerySupported(version) {
+		return "", fmt.Errorf("reading the weather needs Minecraft %s or later", inlinePredicateVersion)
+	}
+	raining, err := c.testWeather("raining")
+	if err != nil {
+		return "", err
+	}
+	if !raining {
+		return "clear", nil
+	}
+	// Thunder only shows while it rains too.
+	thundering, err := c.testWeather("thundering")
+	if err != nil {
+		return "", err
+	}
+	if !thundering {
+		return "rain", nil
+	}
+	return "thunder", nil
+}
+
+func (c Client) testWeather(field string) (bool, error) {
+	out, err := c.send(fmt.Sprintf(`execute if predicate {condition:"minecraft:weather_check",%s:true}`, field))
+	if err != nil {
+		return false, err
+	}
+	switch {
+	case strings.Contains(out, "Test passed"):
+		return true, nil
+	case strings.Contains(out, "Test failed"):
+		return false, nil
+	}
+	return false, fmt.Errorf("unexpected response: %q", strings.TrimSpace(out))
+}
@@ -0,0 +1,131 @@
+package minecraft
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// WorldBorder is the world border's settings.
+type WorldBorder struct {
+	CenterX, CenterZ float64
+	Size             float64 // width in blocks
+
+	// Players outside the border take DamageAmount per second for each
+	// block they are beyond DamageBuffer.
+	DamageAmount float64
+	DamageBuffer float64
+
+	// Players see a warning when within WarningDistance blocks of the
+	// border, or when a shrinking border will reach them within
+	// WarningTime seconds.
+	WarningDistance int
+	WarningTime     int
+}
+
+// DefaultWorldBorder is the border of a new world.
+var DefaultWorldBorder = WorldBorder{
+	Size:            59999968,
+	DamageAmount:    0.2,
+	DamageBuffer:    5,
+	WarningDistance: 5,
+	WarningTime:     15,
+}
+
+// WorldBorderFromLevel reads the border from level.dat's Data compound. A
+// border that is still growing or shrinking reports the size it is heading
+// for. Settings missing from it keep their defaults.
+func WorldBorderFromLevel(level nbt.Compound) WorldBorder {
+	b := DefaultWorldBorder
+	for key, dst := range map[string]*float64{
+		"BorderCenterX":        &b.CenterX,
+		"BorderCenterZ":        &b.CenterZ,
+		"BorderSize":           &b.Size,
+		"BorderDamagePerBlock": &b.DamageAmount,
+		"BorderSafeZone":       &b.DamageBuffer,
+	} {
+		if v, ok := level[key].(float64); ok {
+			*dst = v
+		}
+	}
+	if t, ok := level["BorderSizeLerpTime"].(int64); ok && t > 0 {
+		if v, ok := level["BorderSizeLerpTarget"].(float64); ok {
+			b.Size = v
+		}
+	}
+	if v, ok := level["BorderWarningBlocks"].(float64); ok {
+		b.WarningDistance = int(v)
+	}
+	if v, ok := level["BorderWarningTime"].(float64); ok {
+		b.WarningTime = int(v)
+	}
+	return b
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// SetWorldBorderCenter moves the centre of the border.
+func (c Client) SetWorldBorderCenter(ctx context.Context, x, z float64) error {
+	_, err := c.send(fmt.Sprintf("worldborder center %s %s", formatFloat(x), formatFloat(z)))
+	return err
+}
+
+// SetWorldBorderSize sets the width of the border, growing or shrinking it
+// over the given number of seconds when that is above zero.
+func (c Client) SetWorldBorderSize(ctx context.Context, size float64, seconds int) error {
+	command := fmt.Sprintf("worldborder set %s", formatFloat(size))
+	if seconds > 0 {
+		command += fmt.Sprintf(" %d", seconds)
+	}
+	_, err := c.send(command)
+	return err
+}
+
+// SetWorldBorderDamageAmount sets the damage per second for each block a
+// player is beyond the buffer.
+func (c Client) SetWorldBorderDamageAmount(ctx context.Context, amount float64) error {
+	_, err := c.send(fmt.Sprintf("worldborder damage amount %s", formatFloat(amount)))
+	return err
+}
+
+// SetWorldBorderDamageBuffer sets how far outside the border players can go
+// before taking damage.
+func (c Client) SetWorldBorderDamageBuffer(ctx context.Context, buffer float64) error {
+	_, err := c.send(fmt.Sprintf("worldborder damage buffer %s", formatFloat(buffer)))
+	return err
+}
+
+// SetWorldBorderWarningDistance sets the warning distance in blocks.
+func (c Client) SetWorldBorderWarningDistance(ctx context.Context, distance int) error {
+	_, err := c.send(fmt.Sprintf("worldborder warning distance %d", distance))
+	return err
+}
+
+// SetWorldBorderWarningTime sets the warning time in seconds.
+func (c Client) SetWorldBorderWarningTime(ctx context.Context, seconds int) error {
+	_, err := c.send(fmt.Sprintf("worldborder warning time %d", seconds))
+	return err
+}
+
+// Typical output: The world border is currently 60000000 block(s) wide
+var worldBorderSizePattern = regexp.MustCompile(`currently (-?[\d.]+) block`)
+
+// GetWorldBorderSize returns the current width of the border, which the
+// game rounds to a whole number of blocks.
+func (c Client) GetWorldBorderSize(ctx context.Context) (float64, error) {
+	out, err := c.send("worldborder get")
+	if err != nil {
+		return 0, err
+	}
+	m := worldBorderSizePattern.FindStringSubmatch(out)
+	if m == nil {
+		return 0, fmt.Errorf("unexpected response: %q", strings.TrimSpace(out))
+	}
+	return strconv.ParseFloat(m[1], 64)
+}
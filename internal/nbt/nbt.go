@@ -0,0 +1,408 @@
+// Package nbt reads and writes Minecraft's binary Named Binary Tag format,
+// as used by schematics, structure files and region files.
+//
+// Tags map to Go values as follows: Byte int8, Short int16, Int int32, Long
+// int64, Float float32, Double float64, Byte_Array []byte, String string,
+// List List, Compound Compound, Int_Array []int32 and Long_Array []int64.
+package nbt
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// Tag types.
+const (
+	TagEnd byte = iota
+	TagByte
+	TagShort
+	TagInt
+	TagLong
+	TagFloat
+	TagDouble
+	TagByteArray
+	TagString
+	TagList
+	TagCompound
+	TagIntArray
+	TagLongArray
+)
+
+// maxDepth bounds nesting so a corrupt file can't exhaust the stack.
+const maxDepth = 512
+
+// maxArrayBytes bounds the memory an array or list length may ask for, so a
+// corrupt file can't exhaust memory. Real files stay far below it.
+const maxArrayBytes = 64 << 20
+
+// Compound is a tag holding named tags.
+type Compound map[string]interface{}
+
+// List is a tag holding unnamed tags of one type.
+type List struct {
+	Type  byte
+	Items []interface{}
+}
+
+// Read decodes a root compound, gunzipping or inflating the input first if
+// it is compressed. It returns the root's name and value.
+func Read(r io.Reader) (string, Compound, error) {
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(2)
+	var in io.Reader = br
+	switch {
+	case len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b:
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return "", nil, err
+		}
+		defer gz.Close()
+		in = gz
+	case len(magic) == 2 && magic[0] == 0x78:
+		zr, err := zlib.NewReader(br)
+		if err != nil {
+			return "", nil, err
+		}
+		defer zr.Close()
+		in = zr
+	}
+
+	d := &decoder{r: bufio.NewReader(in)}
+	tag, err := d.byte()
+	if err != nil {
+		return "", nil, err
+	}
+	if tag != TagCompound {
+		return "", nil, fmt.Errorf("root tag is type %d, not a compound", tag)
+	}
+	name, err := d.string()
+	if err != nil {
+		return "", nil, err
+	}
+	v, err := d.payload(TagCompound, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	return name, v.(Compound), nil
+}
+
+// ReadFile reads a (possibly compressed) NBT file.
+func ReadFile(path string) (string, Compound, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	name, root, err := Read(f)
+	if err != nil {
+		return "", nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return name, root, nil
+}
+
+type decoder struct {
+	r   *bufio.Reader
+	buf [8]byte
+}
+
+func (d *decoder) byte() (byte, error) {
+	return d.r.ReadByte()
+}
+
+func (d *decoder) read(n int) ([]byte, error) {
+	if _, err := io.ReadFull(d.r, d.buf[:n]); err != nil {
+		return nil, err
+	}
+	return d.buf[:n], nil
+}
+
+func (d *decoder) int16() (int16, error) {
+	b, err := d.read(2)
+	if err != nil {
+		return 0, err
+	}
+	return int16(binary.BigEndian.Uint16(b)), nil
+}
+
+func (d *decoder) int32() (int32, error) {
+	b, err := d.read(4)
+	if err != nil {
+		return 0, err
+	}
+	return int32(binary.BigEndian.Uint32(b)), nil
+}
+
+func (d *decoder) int64() (int64, error) {
+	b, err := d.read(8)
+	if err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b)), nil
+}
+
+// length reads an array or list length whose elements take at least size
+// bytes each.
+func (d *decoder) length(size int) (int, error) {
+	n, err := d.int32()
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("negative length %d", n)
+	}
+	if int64(n)*int64(size) > maxArrayBytes {
+		return 0, fmt.Errorf("length %d is larger than %d bytes", n, maxArrayBytes)
+	}
+	return int(n), nil
+}
+
+func (d *decoder) string() (string, error) {
+	n, err := d.int16()
+	if err != nil {
+		return "", err
+	}
+	b := make([]byte, uint16(n))
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (d *decoder) payload(tag byte, depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("nesting deeper than %d", maxDepth)
+	}
+
+	switch tag {
+	case TagByte:
+		b, err := d.byte()
+		return int8(b), err
+	case TagShort:
+		return d.int16()
+	case TagInt:
+		return d.int32()
+	case TagLong:
+		return d.int64()
+	case TagFloat:
+		n, err := d.int32()
+		return math.Float32frombits(uint32(n)), err
+	case TagDouble:
+		n, err := d.int64()
+		return math.Float64frombits(uint64(n)), err
+	case TagByteArray:
+		n, err := d.length(1)
+		if err != nil {
+			return nil, err
+		}
+		b := make([]byte, n)
+		_, err = io.ReadFull(d.r, b)
+		return b, err
+	case TagString:
+		return d.string()
+	case TagList:
+		elem, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		n, err := d.length(1)
+		if err != nil {
+			return nil, err
+		}
+		list := List{Type: elem}
+		for i := 0; i < n; i++ {
+			v, err := d.payload(elem, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			list.Items = append(list.Items, v)
+		}
+		return list, nil
+	case TagCompound:
+		c := Compound{}
+		for {
+			t, err := d.byte()
+			if err != nil {
+				return nil, err
+			}
+			if t == TagEnd {
+				return c, nil
+			}
+			name, err := d.string()
+			if err != nil {
+				return nil, err
+			}
+			v, err := d.payload(t, depth+1)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			c[name] = v
+		}
+	case TagIntArray:
+		n, err := d.length(4)
+		if err != nil {
+			return nil, err
+		}
+		a := make([]int32, n)
+		for i := range a {
+			if a[i], err = d.int32(); err != nil {
+				return nil, err
+			}
+		}
+		return a, nil
+	case TagLongArray:
+		n, err := d.length(8)
+		if err != nil {
+			return nil, err
+		}
+		a := make([]int64, n)
+		for i := range a {
+			if a[i], err = d.int64(); err != nil {
+				return nil, err
+			}
+		}
+		return a, nil
+	}
+	return nil, fmt.Errorf("unknown tag type %d", tag)
+}
+
+// TypeOf returns the tag type for a Go value, or TagEnd if it has none.
+func TypeOf(v interface{}) byte {
+	switch v.(type) {
+	case int8:
+		return TagByte
+	case int16:
+		return TagShort
+	case int32:
+		return TagInt
+	case int64:
+		return TagLong
+	case float32:
+		return TagFloat
+	case float64:
+		return TagDouble
+	case []byte:
+		return TagByteArray
+	case string:
+		return TagString
+	case List:
+		return TagList
+	case Compound:
+		return TagCompound
+	case []int32:
+		return TagIntArray
+	case []int64:
+		return TagLongArray
+	}
+	return TagEnd
+}
+
+// Write encodes root as a named root compound, uncompressed.
+func Write(w io.Writer, name string, root Compound) error {
+	bw := bufio.NewWriter(w)
+	e := &encoder{w: bw}
+	e.byte(TagCompound)
+	e.string(name)
+	e.payload(root)
+	if e.err != nil {
+		return e.err
+	}
+	return bw.Flush()
+}
+
+// WriteFile writes root to a gzipped NBT file, the format schematics use.
+func WriteFile(path, name string, root Compound) error {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if err := Write(gz, name, root); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// encoder writes big-endian values and remembers the first error.
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) byte(b byte) {
+	if e.err == nil {
+		e.err = e.w.WriteByte(b)
+	}
+}
+
+func (e *encoder) write(v interface{}) {
+	if e.err == nil {
+		e.err = binary.Write(e.w, binary.BigEndian, v)
+	}
+}
+
+func (e *encoder) string(s string) {
+	if len(s) > math.MaxUint16 {
+		e.err = fmt.Errorf("string of %d bytes is too long", len(s))
+		return
+	}
+	e.write(uint16(len(s)))
+	if e.err == nil {
+		_, e.err = e.w.WriteString(s)
+	}
+}
+
+func (e *encoder) payload(v interface{}) {
+	switch v := v.(type) {
+	case int8, int16, int32, int64, float32, float64:
+		e.write(v)
+	case []byte:
+		e.write(int32(len(v)))
+		if e.err == nil {
+			_, e.err = e.w.Write(v)
+		}
+	case string:
+		e.string(v)
+	case List:
+		elem := v.Type
+		if len(v.Items) > 0 {
+			elem = TypeOf(v.Items[0])
+		}
+		e.byte(elem)
+		e.write(int32(len(v.Items)))
+		for _, item := range v.Items {
+			if TypeOf(item) != elem {
+				e.err = fmt.Errorf("list mixes tag types %d and %d", elem, TypeOf(item))
+				return
+			}
+			e.payload(item)
+		}
+	case Compound:
+		for _, k := range sortedKeys(v) {
+			t := TypeOf(v[k])
+			if t == TagEnd {
+				e.err = fmt.Errorf("%s: unsupported value of type %T", k, v[k])
+				return
+			}
+			e.byte(t)
+			e.string(k)
+			e.payload(v[k])
+		}
+		e.byte(TagEnd)
+	case []int32:
+		e.write(int32(len(v)))
+		e.write(v)
+	case []int64:
+		e.write(int32(len(v)))
+		e.write(v)
+	default:
+		e.err = fmt.Errorf("unsupported value of type %T", v)
+	}
+}
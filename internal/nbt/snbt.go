@@ -0,0 +1,398 @@
+package nbt
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Stringify renders a value as SNBT, the text form commands such as
+// `summon` and `data merge` accept.
+func Stringify(v interface{}) string {
+	var b strings.Builder
+	stringify(&b, v)
+	return b.String()
+}
+
+func stringify(b *strings.Builder, v interface{}) {
+	switch v := v.(type) {
+	case int8:
+		fmt.Fprintf(b, "%db", v)
+	case int16:
+		fmt.Fprintf(b, "%ds", v)
+	case int32:
+		fmt.Fprintf(b, "%d", v)
+	case int64:
+		fmt.Fprintf(b, "%dL", v)
+	case float32:
+		b.WriteString(strconv.FormatFloat(float64(v), 'g', -1, 32) + "f")
+	case float64:
+		b.WriteString(strconv.FormatFloat(v, 'g', -1, 64) + "d")
+	case string:
+		b.WriteString(quote(v))
+	case []byte:
+		b.WriteString("[B;")
+		for i, x := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(b, "%db", int8(x))
+		}
+		b.WriteByte(']')
+	case []int32:
+		b.WriteString("[I;")
+		for i, x := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(b, "%d", x)
+		}
+		b.WriteByte(']')
+	case []int64:
+		b.WriteString("[L;")
+		for i, x := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			fmt.Fprintf(b, "%dL", x)
+		}
+		b.WriteByte(']')
+	case List:
+		b.WriteByte('[')
+		for i, item := range v.Items {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			stringify(b, item)
+		}
+		b.WriteByte(']')
+	case Compound:
+		b.WriteByte('{')
+		for i, k := range sortedKeys(v) {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			if bareKey(k) {
+				b.WriteString(k)
+			} else {
+				b.WriteString(quote(k))
+			}
+			b.WriteByte(':')
+			stringify(b, v[k])
+		}
+		b.WriteByte('}')
+	}
+}
+
+// bareKey reports whether a compound key can be written without quotes.
+func bareKey(k string) bool {
+	if k == "" {
+		return false
+	}
+	for _, ch := range k {
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || strings.ContainsRune("_-.+", ch)) {
+			return false
+		}
+	}
+	return true
+}
+
+// quote quotes s with whichever quote character needs less escaping.
+func quote(s string) string {
+	q := `"`
+	if strings.Contains(s, `"`) && !strings.Contains(s, `'`) {
+		q = `'`
+	}
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, q, `\`+q)
+	return q + s + q
+}
+
+func sortedKeys(c Compound) []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Parse decodes SNBT, such as the output of `data get block`, into typed
+// values. It is the inverse of Stringify. Numbers without a suffix are ints,
+// or doubles if they have a decimal point; true and false are bytes.
+func Parse(snbt string) (interface{}, error) {
+	p := &parser{s: snbt}
+	v, err := p.value(0)
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.i != len(p.s) {
+		return nil, fmt.Errorf("unexpected data at offset %d", p.i)
+	}
+	return v, nil
+}
+
+// ParseCompound is Parse for input that must be a compound.
+func ParseCompound(snbt string) (Compound, error) {
+	v, err := Parse(snbt)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := v.(Compound)
+	if !ok {
+		return nil, fmt.Errorf("expected a compound")
+	}
+	return c, nil
+}
+
+type parser struct {
+	s string
+	i int
+}
+
+func (p *parser) skipSpace() {
+	for p.i < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.i]) >= 0 {
+		p.i++
+	}
+}
+
+func (p *parser) peek() byte {
+	p.skipSpace()
+	if p.i >= len(p.s) {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+func (p *parser) value(depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("nesting too deep")
+	}
+	switch p.peek() {
+	case '{':
+		return p.compound(depth)
+	case '[':
+		return p.list(depth)
+	case '"', '\'':
+		return p.quoted()
+	case 0:
+		return nil, fmt.Errorf("unexpected end of input")
+	}
+	return scalar(p.unquoted())
+}
+
+func (p *parser) compound(depth int) (Compound, error) {
+	p.i++ // {
+	c := Compound{}
+	if p.peek() == '}' {
+		p.i++
+		return c, nil
+	}
+	for {
+		var key string
+		var err error
+		switch p.peek() {
+		case '"', '\'':
+			key, err = p.quoted()
+		default:
+			key = p.unquoted()
+			if key == "" {
+				err = fmt.Errorf("expected key at offset %d", p.i)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ':' {
+			return nil, fmt.Errorf("expected ':' at offset %d", p.i)
+		}
+		p.i++
+		v, err := p.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		c[key] = v
+
+		switch p.peek() {
+		case ',':
+			p.i++
+		case '}':
+			p.i++
+			return c, nil
+		default:
+			return nil, fmt.Errorf("expected ',' or '}' at offset %d", p.i)
+		}
+	}
+}
+
+func (p *parser) list(depth int) (interface{}, error) {
+	p.i++ // [
+	array := byte(0)
+	if p.i+1 < len(p.s) && p.s[p.i+1] == ';' && strings.IndexByte("BIL", p.s[p.i]) >= 0 {
+		array = p.s[p.i]
+		p.i += 2
+	}
+
+	var items []interface{}
+	if p.peek() == ']' {
+		p.i++
+	} else {
+		for {
+			v, err := p.value(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+
+			switch p.peek() {
+			case ',':
+				p.i++
+				continue
+			case ']':
+				p.i++
+			default:
+				return nil, fmt.Errorf("expected ',' or ']' at offset %d", p.i)
+			}
+			break
+		}
+	}
+
+	switch array {
+	case 'B':
+		out := make([]byte, len(items))
+		for i, v := range items {
+			n, ok := integer(v)
+			if !ok {
+				return nil, fmt.Errorf("byte array holds %v", v)
+			}
+			out[i] = byte(n)
+		}
+		return out, nil
+	case 'I':
+		out := make([]int32, len(items))
+		for i, v := range items {
+			n, ok := integer(v)
+			if !ok {
+				return nil, fmt.Errorf("int array holds %v", v)
+			}
+			out[i] = int32(n)
+		}
+		return out, nil
+	case 'L':
+		out := make([]int64, len(items))
+		for i, v := range items {
+			n, ok := integer(v)
+			if !ok {
+				return nil, fmt.Errorf("long array holds %v", v)
+			}
+			out[i] = n
+		}
+		return out, nil
+	}
+
+	l := List{Type: TagEnd, Items: items}
+	if len(items) > 0 {
+		l.Type = TypeOf(items[0])
+		for _, v := range items[1:] {
+			if TypeOf(v) != l.Type {
+				return nil, fmt.Errorf("list mixes tag types %d and %d", l.Type, TypeOf(v))
+			}
+		}
+	}
+	return l, nil
+}
+
+func (p *parser) quoted() (string, error) {
+	q := p.s[p.i]
+	p.i++
+	var b strings.Builder
+	for p.i < len(p.s) {
+		ch := p.s[p.i]
+		p.i++
+		switch {
+		case ch == '\\' && p.i < len(p.s):
+			b.WriteByte(p.s[p.i])
+			p.i++
+		case ch == q:
+			return b.String(), nil
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+func (p *parser) unquoted() string {
+	p.skipSpace()
+	start := p.i
+	for p.i < len(p.s) {
+		ch := rune(p.s[p.i])
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || strings.ContainsRune("_-.+", ch)) {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// scalar types an unquoted token by its suffix, falling back to a string.
+func scalar(token string) (interface{}, error) {
+	switch token {
+	case "":
+		return nil, fmt.Errorf("expected value")
+	case "true":
+		return int8(1), nil
+	case "false":
+		return int8(0), nil
+	}
+
+	body, suffix := token[:len(token)-1], strings.ToLower(token[len(token)-1:])
+	switch suffix {
+	case "b":
+		if n, err := strconv.ParseInt(body, 10, 8); err == nil {
+			return int8(n), nil
+		}
+	case "s":
+		if n, err := strconv.ParseInt(body, 10, 16); err == nil {
+			return int16(n), nil
+		}
+	case "l":
+		if n, err := strconv.ParseInt(body, 10, 64); err == nil {
+			return n, nil
+		}
+	case "f":
+		if f, err := strconv.ParseFloat(body, 32); err == nil {
+			return float32(f), nil
+		}
+	case "d":
+		if f, err := strconv.ParseFloat(body, 64); err == nil {
+			return f, nil
+		}
+	}
+	if n, err := strconv.ParseInt(token, 10, 32); err == nil {
+		return int32(n), nil
+	}
+	if strings.Contains(token, ".") {
+		if f, err := strconv.ParseFloat(token, 64); err == nil {
+			return f, nil
+		}
+	}
+	return token, nil
+}
+
+// integer returns the value of an integer tag.
+func integer(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	}
+	return 0, false
+}
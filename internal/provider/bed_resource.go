@@ -3,17 +3,20 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ tfsdk.ResourceType = bedResourceType{}
 var _ tfsdk.Resource = bedResource{}
 var _ tfsdk.ResourceWithImportState = bedResource{}
+var _ tfsdk.ResourceWithModifyPlan = bedResource{}
 
 type bedResourceType struct{}
 
@@ -21,10 +24,23 @@ func (t bedResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diag
 	return tfsdk.Schema{
 		MarkdownDescription: "A Minecraft bed (two-block structure). The start position is the FOOT. Direction places the HEAD one block in that direction.",
 		Attributes: map[string]tfsdk.Attribute{
+			"color": {
+				MarkdownDescription: "The bed colour, one of the 16 dye colours, e.g. `red`, `light_blue`. Set either `color` or `material`.",
+				Optional:            true,
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
 			"material": {
-				MarkdownDescription: "The bed material, e.g. `minecraft:red_bed`, `minecraft:blue_bed`.",
-				Required:            true,
+				MarkdownDescription: "The bed material, e.g. `minecraft:red_bed`, `minecraft:blue_bed`. Set either `color` or `material`.",
+				Optional:            true,
+				Computed:            true,
 				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
 			},
 			"position": {
 				MarkdownDescription: "The FOOT position of the bed.",
@@ -61,12 +77,12 @@ func (t bedResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diag
 				Required:            true,
 				Type:                types.StringType,
 			},
-			// Optional convenience flag (defaults handled in code as false)
 			"occupied": {
-				MarkdownDescription: "Whether the bed is considered occupied (rarely needed). Defaults to false.",
+				MarkdownDescription: "Whether the bed is marked occupied. Written to the block state of both halves. Defaults to false.",
 				Optional:            true,
 				Type:                types.BoolType,
 			},
+			"dimension": dimensionAttribute(),
 			"id": {
 				Computed:            true,
 				MarkdownDescription: "ID of the bed resource.",
@@ -85,9 +101,11 @@ func (t bedResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tf
 }
 
 type bedResourceData struct {
-	Id       types.String `tfsdk:"id"`
-	Material string       `tfsdk:"material"`
-	Position struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Color     types.String `tfsdk:"color"`
+	Material  types.String `tfsdk:"material"`
+	Position  struct {
 		X int `tfsdk:"x"`
 		Y int `tfsdk:"y"`
 		Z int `tfsdk:"z"`
@@ -96,6 +114,77 @@ type bedResourceData struct {
 	Occupied  *bool  `tfsdk:"occupied"`  // optional
 }
 
+// resolve fills in material from color or the other way round and checks the
+// two agree.
+func (d *bedResourceData) resolve() error {
+	color := knownString(d.Color)
+	material := minecraft.NormalizeMaterial(knownString(d.Material))
+
+	switch {
+	case color == "" && material == "":
+		return fmt.Errorf("one of color or material must be set")
+	case color == "":
+		color = bedColor(material)
+		if color == "" {
+			return fmt.Errorf("material must be one of the 16 dye-coloured beds, e.g. minecraft:red_bed (got %q)", material)
+		}
+	case !minecraft.IsDyeColor(color):
+		return fmt.Errorf("color must be one of: %s (got %q)", strings.Join(minecraft.DyeColors, ", "), color)
+	case material == "":
+		material = bedMaterial(color)
+	case material != bedMaterial(color):
+		return fmt.Errorf("color %q and material %q do not match", color, material)
+	}
+
+	if _, _, ok := bedOffset(d.Direction); !ok {
+		return fmt.Errorf("direction must be one of north|south|east|west")
+	}
+
+	d.Color = types.String{Value: color}
+	d.Material = types.String{Value: material}
+	return nil
+}
+
+func (d bedResourceData) occupied() bool {
+	return d.Occupied != nil && *d.Occupied
+}
+
+// head returns the position of the HEAD half.
+func (d bedResourceData) head() (x, y, z int) {
+	dx, dz, _ := bedOffset(d.Direction)
+	return d.Position.X + dx, d.Position.Y, d.Position.Z + dz
+}
+
+// half renders the block state of the foot or head half.
+func (d bedResourceData) half(part string) string {
+	return minecraft.BlockWithState(d.Material.Value, map[string]string{
+		"facing":   d.Direction,
+		"part":     part,
+		"occupied": fmt.Sprintf("%t", d.occupied()),
+	})
+}
+
+func bedMaterial(color string) string {
+	return fmt.Sprintf("minecraft:%s_bed", color)
+}
+
+// bedColor returns the dye colour of a bed material, or "" if it is not a bed.
+func bedColor(material string) string {
+	color := strings.TrimSuffix(strings.TrimPrefix(material, "minecraft:"), "_bed")
+	if color == material || !minecraft.IsDyeColor(color) {
+		return ""
+	}
+	return color
+}
+
+// knownString returns the value of s, or "" when it is null or unknown.
+func knownString(s types.String) string {
+	if s.Null || s.Unknown {
+		return ""
+	}
+	return s.Value
+}
+
 type bedResource struct {
 	provider provider
 }
@@ -116,46 +205,77 @@ func bedOffset(facing string) (dx, dz int, valid bool) {
 	}
 }
 
+// placeBed places both halves in one transaction and checks they stuck. A
+// bed half without its partner is dropped by the game, so on any failure
+// both positions are rolled back rather than leaving an orphaned foot.
+func placeBed(ctx context.Context, tx *minecraft.Transaction, client *minecraft.Client, data bedResourceData, hint string) error {
+	hx, hy, hz := data.head()
+	if err := tx.SetBlock(ctx, hint, data.half("foot"), data.Position.X, data.Position.Y, data.Position.Z); err != nil {
+		return err
+	}
+	if err := tx.SetBlock(ctx, hint, data.half("head"), hx, hy, hz); err != nil {
+		return err
+	}
+
+	ok, err := bedInPlace(ctx, client, data)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("the bed did not stay in place at %d %d %d", data.Position.X, data.Position.Y, data.Position.Z)
+	}
+	return nil
+}
+
+// bedInPlace reports whether both halves match the material and direction.
+func bedInPlace(ctx context.Context, client *minecraft.Client, data bedResourceData) (bool, error) {
+	hx, hy, hz := data.head()
+	for _, half := range []struct {
+		part    string
+		x, y, z int
+	}{
+		{"foot", data.Position.X, data.Position.Y, data.Position.Z},
+		{"head", hx, hy, hz},
+	} {
+		block := minecraft.BlockWithState(data.Material.Value, map[string]string{"facing": data.Direction, "part": half.part})
+		ok, err := client.TestBlock(ctx, block, half.x, half.y, half.z)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 func (r bedResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data bedResourceData
-	diags := req.Config.Get(ctx, &data)
+	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	dx, dz, ok := bedOffset(data.Direction)
-	if !ok {
-		resp.Diagnostics.AddError("Validation Error", "direction must be one of north|south|east|west")
+	if err := data.resolve(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
 		return
 	}
 
-	occupied := false
-	if data.Occupied != nil {
-		occupied = *data.Occupied
-	}
-
-	// Place FOOT at start position
-	footMat := fmt.Sprintf(`%s[facing=%s,part=foot,occupied=%t]`, data.Material, data.Direction, occupied)
-	if err := client.CreateBlock(ctx, footMat, data.Position.X, data.Position.Y, data.Position.Z); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place bed foot: %s", err))
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
 		return
 	}
 
-	// Place HEAD one block in facing direction
-	headX := data.Position.X + dx
-	headZ := data.Position.Z + dz
-	headMat := fmt.Sprintf(`%s[facing=%s,part=head,occupied=%t]`, data.Material, data.Direction, occupied)
-	if err := client.CreateBlock(ctx, headMat, headX, data.Position.Y, headZ); err != nil {
-		// Roll back foot on failure
-		_ = client.DeleteBlock(ctx, data.Position.X, data.Position.Y, data.Position.Z)
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place bed head: %s", err))
+	err = client.Transact(ctx, registry, func(tx *minecraft.Transaction) error {
+		return placeBed(ctx, tx, client, data, "")
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place bed, got error: %s", err))
 		return
 	}
 
@@ -165,54 +285,107 @@ func (r bedResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest
 }
 
 func (r bedResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
-	// No read API; keep state as-is
 	var data bedResourceData
 	diags := req.State.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if err := data.resolve(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
+		return
+	}
+
+	ok, err := bedInPlace(ctx, client, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read bed, got error: %s", err))
+		return
+	}
+	if !ok {
+		// The bed may have been swapped for another colour in place.
+		found := false
+		for _, color := range minecraft.DyeColors {
+			candidate := data
+			candidate.Color = types.String{Value: color}
+			candidate.Material = types.String{Value: bedMaterial(color)}
+			if candidate.Material.Value == data.Material.Value {
+				continue
+			}
+			if found, err = bedInPlace(ctx, client, candidate); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read bed, got error: %s", err))
+				return
+			}
+			if found {
+				data = candidate
+				break
+			}
+		}
+		if !found {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+	}
+
+	occupied, err := client.TestBlock(ctx, minecraft.BlockWithState(data.Material.Value, map[string]string{"part": "foot", "occupied": "true"}), data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read bed, got error: %s", err))
+		return
+	}
+	if data.Occupied != nil || occupied {
+		data.Occupied = &occupied
+	}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r bedResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
-	var data bedResourceData
+	var data, prior bedResourceData
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	dx, dz, ok := bedOffset(data.Direction)
-	if !ok {
-		resp.Diagnostics.AddError("Validation Error", "direction must be one of north|south|east|west")
+	if err := data.resolve(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
 		return
 	}
 
-	occupied := false
-	if data.Occupied != nil {
-		occupied = *data.Occupied
-	}
-
-	// Re-place both parts
-	footMat := fmt.Sprintf(`%s[facing=%s,part=foot,occupied=%t]`, data.Material, data.Direction, occupied)
-	if err := client.CreateBlock(ctx, footMat, data.Position.X, data.Position.Y, data.Position.Z); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update bed foot: %s", err))
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
 		return
 	}
 
-	headX := data.Position.X + dx
-	headZ := data.Position.Z + dz
-	headMat := fmt.Sprintf(`%s[facing=%s,part=head,occupied=%t]`, data.Material, data.Direction, occupied)
-	if err := client.CreateBlock(ctx, headMat, headX, data.Position.Y, headZ); err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update bed head: %s", err))
+	err = client.Transact(ctx, registry, func(tx *minecraft.Transaction) error {
+		// Turning the bed moves the head: clear the old one first.
+		if prior.Direction != data.Direction {
+			if _, _, ok := bedOffset(prior.Direction); ok {
+				hx, hy, hz := prior.head()
+				if err := tx.DeleteBlock(ctx, prior.Material.Value, hx, hy, hz); err != nil {
+					return err
+				}
+			}
+		}
+		return placeBed(ctx, tx, client, data, prior.Material.Value)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update bed, got error: %s", err))
 		return
 	}
 
@@ -228,12 +401,7 @@ func (r bedResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest
 		return
 	}
 
-	dx, dz, ok := bedOffset(data.Direction)
-	if !ok {
-		// Even if invalid, at least delete the foot
-	}
-
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
 		return
@@ -243,10 +411,35 @@ func (r bedResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest
 	_ = client.DeleteBlock(ctx, data.Position.X, data.Position.Y, data.Position.Z)
 
 	// Delete head (based on stored direction)
-	if ok {
-		headX := data.Position.X + dx
-		headZ := data.Position.Z + dz
-		_ = client.DeleteBlock(ctx, headX, data.Position.Y, headZ)
+	if _, _, ok := bedOffset(data.Direction); ok {
+		hx, hy, hz := data.head()
+		_ = client.DeleteBlock(ctx, hx, hy, hz)
+	}
+}
+
+// ModifyPlan checks color, material and direction before anything is placed,
+// and plans whichever of color and material is derived from the other.
+func (r bedResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data bedResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Color.Unknown || data.Material.Unknown || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	config := data
+	if err := data.resolve(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+	if config.Color.Null {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("color"), data.Color)...)
+	}
+	if config.Material.Null {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("material"), data.Material)...)
 	}
 }
 
@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/anvil"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = biomeResourceType{}
+var _ tfsdk.Resource = biomeResource{}
+var _ tfsdk.ResourceWithImportState = biomeResource{}
+var _ tfsdk.ResourceWithModifyPlan = biomeResource{}
+
+// biomeCheckLimit is the most cells refresh tests over RCON, one command
+// each. Larger regions are sampled at an even spacing.
+const biomeCheckLimit = 4096
+
+type biomeResourceType struct{}
+
+func (t biomeResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Set the **biome** of a cuboid region, whatever the world generated there (wraps `/fillbiome`, Minecraft 1.19.3 or later).",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"start": fixedPosition("Inclusive start corner of the cuboid."),
+			"end":   fixedPosition("Inclusive end corner of the cuboid."),
+			"biome": {
+				MarkdownDescription: "Biome ID to set, e.g. `minecraft:cherry_grove`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"replace": {
+				MarkdownDescription: "Only change cells whose biome matches this biome ID or `#` tag, e.g. `minecraft:plains` or `#minecraft:is_forest`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the biome region.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t biomeResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return biomeResource{provider: provider}, diags
+}
+
+type biomeResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Start     struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"start"`
+	End struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"end"`
+	Biome   string       `tfsdk:"biome"`
+	Replace types.String `tfsdk:"replace"`
+}
+
+func (d biomeResourceData) box() minecraft.Box {
+	return minecraft.NewBox(
+		minecraft.Pos{X: d.Start.X, Y: d.Start.Y, Z: d.Start.Z},
+		minecraft.Pos{X: d.End.X, Y: d.End.Y, Z: d.End.Z},
+	)
+}
+
+type biomeResource struct {
+	provider provider
+}
+
+// fill runs `fillbiome` over the region.
+func (r biomeResource) fill(ctx context.Context, data biomeResourceData, diags *diag.Diagnostics) {
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+	if _, err := client.FillBiome(ctx, r.provider.ServerVersion(), data.box(), data.Biome, data.Replace.Value); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to fill biome, got error: %s", err))
+	}
+}
+
+func (r biomeResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data biomeResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.fill(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.String{Value: fmt.Sprintf("biome-%d-%d-%d", data.Start.X, data.Start.Y, data.Start.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read looks for cells that lost the biome. With world files every cell is
+// checked and the first wrong biome is recorded; over RCON cells are tested
+// with `execute if biome`, sampled in large regions, and a miss is recorded
+// as an empty biome. Cells in unloaded chunks are skipped.
+// A region with a replace filter is not checked, as cells that didn't match
+// the filter keep their own biome.
+func (r biomeResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data biomeResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Replace.Null && minecraft.FillBiomeSupported(r.provider.ServerVersion()) {
+		biome, err := r.drift(ctx, data)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read biome, got error: %s", err))
+			return
+		}
+		data.Biome = biome
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// drift returns data.Biome if the region still has it, or what was found
+// instead.
+func (r biomeResource) drift(ctx context.Context, data biomeResourceData) (string, error) {
+	want := minecraft.NormalizeMaterial(data.Biome)
+	box := data.box()
+
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		return "", err
+	}
+	if world != nil {
+		dimension := world.Dimension(minecraft.NormalizeDimension(data.Dimension.Value))
+		for y := box.Min.Y &^ 3; y <= box.Max.Y; y += 4 {
+			for z := box.Min.Z &^ 3; z <= box.Max.Z; z += 4 {
+				for x := box.Min.X &^ 3; x <= box.Max.X; x += 4 {
+					got, err := dimension.Biome(x, y, z)
+					if errors.Is(err, anvil.ErrNotGenerated) {
+						continue
+					}
+					if err != nil {
+						return "", err
+					}
+					if got != want {
+						return got, nil
+					}
+				}
+			}
+		}
+		return data.Biome, nil
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		return "", err
+	}
+	cells := (box.Max.X>>2 - box.Min.X>>2 + 1) * (box.Max.Y>>2 - box.Min.Y>>2 + 1) * (box.Max.Z>>2 - box.Min.Z>>2 + 1)
+	step := 4
+	for cells > biomeCheckLimit*(step/4)*(step/4)*(step/4) {
+		step += 4
+	}
+	for y := box.Min.Y &^ 3; y <= box.Max.Y; y += step {
+		for z := box.Min.Z &^ 3; z <= box.Max.Z; z += step {
+			for x := box.Min.X &^ 3; x <= box.Max.X; x += step {
+				ok, err := client.TestBiome(ctx, want, x, y, z)
+				if errors.Is(err, minecraft.ErrNotLoaded) {
+					continue
+				}
+				if err != nil {
+					return "", err
+				}
+				if !ok {
+					// The biome in its place is unknown; any value that
+					// differs from the configuration makes the plan fill
+					// the region again.
+					return "", nil
+				}
+			}
+		}
+	}
+	return data.Biome, nil
+}
+
+func (r biomeResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data biomeResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.fill(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete leaves the biome in place: the biomes it replaced were not
+// recorded, and generation never runs again on existing chunks.
+func (r biomeResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data biomeResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ModifyPlan checks the biome IDs and that the server has `fillbiome`.
+func (r biomeResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data biomeResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := minecraft.ValidateBiome(data.Biome, false); err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("biome"), "Validation Error", err.Error())
+	}
+	if !data.Replace.Null {
+		if err := minecraft.ValidateBiome(data.Replace.Value, true); err != nil {
+			resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("replace"), "Validation Error", err.Error())
+		}
+	}
+	if version := r.provider.ServerVersion(); !minecraft.FillBiomeSupported(version) {
+		resp.Diagnostics.AddError("Unsupported Biome Fill",
+			fmt.Sprintf("Setting biomes needs Minecraft 1.19.3 or later; the server is %s.", version))
+	}
+}
+
+func (r biomeResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
@@ -2,18 +2,23 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces
 var _ tfsdk.ResourceType = blockResourceType{}
 var _ tfsdk.Resource = blockResource{}
 var _ tfsdk.ResourceWithImportState = blockResource{}
+var _ tfsdk.ResourceWithModifyPlan = blockResource{}
 
 type blockResourceType struct{}
 
@@ -27,6 +32,19 @@ func (t blockResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Di
 				Required:            true,
 				Type:                types.StringType,
 			},
+			"nbt": {
+				MarkdownDescription: "Block entity data as an SNBT compound, e.g. `{SpawnData:{entity:{id:\"minecraft:zombie\"}}}` for a spawner or `{SkullOwner:\"Notch\"}` for a player head. Only the given tags are managed.",
+				Optional:            true,
+				Type:                types.StringType,
+				Validators: []tfsdk.AttributeValidator{
+					snbtValidator{},
+				},
+			},
+			"state": {
+				MarkdownDescription: "Block state properties, e.g. `{ axis = \"x\" }` for a log on its side or `{ type = \"top\" }` for a slab. Validated against the block state registry for the server version.",
+				Optional:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+			},
 			"position": {
 				MarkdownDescription: "The position of the block",
 				Required:            true,
@@ -57,6 +75,7 @@ func (t blockResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Di
 					},
 				}),
 			},
+			"dimension": dimensionAttribute(),
 			"id": {
 				Computed:            true,
 				MarkdownDescription: "ID of the block",
@@ -78,9 +97,12 @@ func (t blockResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (
 }
 
 type blockResourceData struct {
-	Id       types.String `tfsdk:"id"`
-	Material string       `tfsdk:"material"`
-	Position struct {
+	Id        types.String      `tfsdk:"id"`
+	Dimension types.String      `tfsdk:"dimension"`
+	Material  string            `tfsdk:"material"`
+	State     map[string]string `tfsdk:"state"`
+	NBT       types.String      `tfsdk:"nbt"`
+	Position  struct {
 		X int `tfsdk:"x"`
 		Y int `tfsdk:"y"`
 		Z int `tfsdk:"z"`
@@ -101,13 +123,15 @@ func (r blockResource) Create(ctx context.Context, req tfsdk.CreateResourceReque
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
 		return
 	}
 
-	err = client.CreateBlock(ctx, data.Material, data.Position.X, data.Position.Y, data.Position.Z)
+	// Block entity data rides along as the setblock NBT suffix.
+	block := minecraft.BlockWithState(data.Material, data.State) + data.NBT.Value
+	err = client.CreateBlock(ctx, block, data.Position.X, data.Position.Y, data.Position.Z)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create block, got error: %s", err))
 		return
@@ -119,7 +143,9 @@ func (r blockResource) Create(ctx context.Context, req tfsdk.CreateResourceReque
 	resp.Diagnostics.Append(diags...)
 }
 
-// It is impossible to read a block without an entity, so we do nothing for now.
+// Read tests the block in the world against state. A different block removes
+// the resource so it is planned for re-creation; drifted state properties are
+// probed for their current value so the plan shows what changed.
 func (r blockResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
 	var data blockResourceData
 
@@ -130,6 +156,90 @@ func (r blockResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest,
 		return
 	}
 
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	// An imported block only knows its position; identify what is there.
+	if data.Material == "" {
+		registry, err := r.provider.BlockStates()
+		if err != nil {
+			resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+			return
+		}
+		snapshot, err := client.CaptureBlock(ctx, registry, nil, data.Position.X, data.Position.Y, data.Position.Z)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read block, got error: %s", err))
+			return
+		}
+		data.Material, data.State = minecraft.ParseBlock(snapshot.Block)
+		if snapshot.NBT != "" {
+			if blockData, err := minecraft.BlockEntityData(snapshot.NBT); err == nil && len(blockData) > 0 {
+				data.NBT = types.String{Value: nbt.Stringify(blockData)}
+			}
+		}
+
+		diags = resp.State.Set(ctx, &data)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	exists, err := client.TestBlock(ctx, data.Material, data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read block, got error: %s", err))
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if len(data.State) > 0 {
+		matches, err := client.TestBlock(ctx, minecraft.BlockWithState(data.Material, data.State), data.Position.X, data.Position.Y, data.Position.Z)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read block state, got error: %s", err))
+			return
+		}
+
+		if !matches {
+			registry, _ := r.provider.BlockStates()
+			candidates := stateCandidates(registry, data.Material, data.State)
+			current, err := client.GetBlockState(ctx, data.Material, candidates, data.Position.X, data.Position.Y, data.Position.Z)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read block state, got error: %s", err))
+				return
+			}
+			data.State = current
+		}
+	}
+
+	// Block predicates match NBT as a subset, so extra tags the server adds
+	// don't count as drift. On a mismatch, surface the block entity without
+	// its ID and position.
+	if data.NBT.Value != "" {
+		matches, err := client.TestBlock(ctx, data.Material+data.NBT.Value, data.Position.X, data.Position.Y, data.Position.Z)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read block data, got error: %s", err))
+			return
+		}
+
+		if !matches {
+			current, err := client.GetBlockData(ctx, data.Position.X, data.Position.Y, data.Position.Z)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read block data, got error: %s", err))
+				return
+			}
+			blockData, err := minecraft.BlockEntityData(current)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse block data, got error: %s", err))
+				return
+			}
+			data.NBT = types.String{Value: nbt.Stringify(blockData)}
+		}
+	}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
@@ -144,18 +254,29 @@ func (r blockResource) Update(ctx context.Context, req tfsdk.UpdateResourceReque
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
 		return
 	}
 
-	err = client.CreateBlock(ctx, data.Material, data.Position.X, data.Position.Y, data.Position.Z)
+	block := minecraft.BlockWithState(data.Material, data.State) + data.NBT.Value
+	err = client.CreateBlock(ctx, block, data.Position.X, data.Position.Y, data.Position.Z)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update block, got error: %s", err))
 		return
 	}
 
+	// setblock is a no-op when the block state is unchanged, so merge the
+	// block entity data explicitly.
+	if data.NBT.Value != "" {
+		err = client.MergeBlockData(ctx, data.NBT.Value, data.Position.X, data.Position.Y, data.Position.Z)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update block data, got error: %s", err))
+			return
+		}
+	}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
@@ -170,7 +291,7 @@ func (r blockResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReque
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
 		return
@@ -183,6 +304,77 @@ func (r blockResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReque
 	}
 }
 
+// ModifyPlan validates `state` against the block state registry for the
+// configured server version. Blocks missing from the registry only warn.
+func (r blockResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	materialPath := tftypes.NewAttributePath().WithAttributeName("material")
+	statePath := tftypes.NewAttributePath().WithAttributeName("state")
+
+	var material types.String
+	var state types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, materialPath, &material)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, statePath, &state)...)
+	if resp.Diagnostics.HasError() || material.Unknown || state.Unknown || state.Null {
+		return
+	}
+
+	values := map[string]string{}
+	for key, elem := range state.Elems {
+		value, ok := elem.(types.String)
+		if !ok || value.Unknown {
+			return
+		}
+		values[key] = value.Value
+	}
+
+	if strings.Contains(material.Value, "[") {
+		resp.Diagnostics.AddAttributeError(statePath, "Invalid Block State", "Set block state properties either inline in `material` or in `state`, not both.")
+		return
+	}
+
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+
+	if err := registry.Validate(material.Value, values); err != nil {
+		if errors.Is(err, minecraft.ErrUnknownBlock) {
+			resp.Diagnostics.AddAttributeWarning(materialPath, "Unvalidated Block State", fmt.Sprintf("%s; block state properties are passed through unchecked.", err))
+			return
+		}
+		resp.Diagnostics.AddAttributeError(statePath, "Invalid Block State", err.Error())
+	}
+}
+
+// ImportState accepts the resource ID, `block-<x>-<y>-<z>`, optionally
+// followed by `@<dimension>`. Read then identifies the block in the world.
 func (r blockResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	id, dimension := req.ID, ""
+	if i := strings.Index(id, "@"); i >= 0 {
+		id, dimension = id[:i], id[i+1:]
+	}
+
+	var x, y, z int
+	if n, err := fmt.Sscanf(id, "block-%d-%d-%d", &x, &y, &z); err != nil || n != 3 || id != fmt.Sprintf("block-%d-%d-%d", x, y, z) {
+		resp.Diagnostics.AddError("Import Error", "Expected `block-<x>-<y>-<z>` or `block-<x>-<y>-<z>@<dimension>` as import ID.")
+		return
+	}
+
+	position := struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	}{x, y, z}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("position"), position)...)
+	// An empty material tells Read to identify the block.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("material"), "")...)
+	if dimension != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("dimension"), dimension)...)
+	}
 }
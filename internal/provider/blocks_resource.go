@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/anvil"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = blocksResourceType{}
+var _ tfsdk.Resource = blocksResource{}
+var _ tfsdk.ResourceWithImportState = blocksResource{}
+
+type blocksResourceType struct{}
+
+func (t blocksResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Many blocks managed as one resource. Adjacent identical blocks are merged into as few `/fill` commands as possible, and updates only touch the blocks that changed.",
+		Attributes: map[string]tfsdk.Attribute{
+			"blocks": {
+				MarkdownDescription: "Blocks keyed by position written as `\"x,y,z\"`, e.g. `{ \"0,64,0\" = \"minecraft:stone\" }`. Values may carry block states such as `minecraft:oak_log[axis=x]`.",
+				Required:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+				Validators: []tfsdk.AttributeValidator{
+					blockPositionKeysValidator{},
+				},
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the blocks resource.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t blocksResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return blocksResource{provider: provider}, diags
+}
+
+type blocksResourceData struct {
+	Id        types.String      `tfsdk:"id"`
+	Dimension types.String      `tfsdk:"dimension"`
+	Blocks    map[string]string `tfsdk:"blocks"`
+}
+
+type blocksResource struct {
+	provider provider
+}
+
+// parseBlockMap converts `"x,y,z" => material` into positions. Two keys that
+// name the same position, e.g. "1,2,3" and "1, 2, 3", are an error.
+func parseBlockMap(blocks map[string]string) (map[minecraft.Pos]string, error) {
+	out := make(map[minecraft.Pos]string, len(blocks))
+	for key, material := range blocks {
+		pos, err := minecraft.ParsePos(key)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := out[pos]; ok {
+			return nil, fmt.Errorf("position %s is listed more than once", pos)
+		}
+		out[pos] = material
+	}
+	return out, nil
+}
+
+// blockMatches reports whether got, a full block state read from the world,
+// is the block want describes. want may leave out state properties, and
+// all kinds of air count as the same block.
+func blockMatches(want, got string) bool {
+	if i := strings.Index(want, "{"); i >= 0 {
+		want = want[:i]
+	}
+	wantID, wantState := minecraft.ParseBlock(minecraft.NormalizeMaterial(want))
+	gotID, gotState := minecraft.ParseBlock(got)
+	if isAirBlock(wantID) && isAirBlock(gotID) {
+		return true
+	}
+	if wantID != gotID {
+		return false
+	}
+	for key, value := range wantState {
+		if gotState[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// driftedBlocks reads the given blocks from the world files and returns the
+// ones that no longer match, with the block that is there now. Blocks in
+// chunks that were never generated are skipped.
+func driftedBlocks(world *anvil.World, dimension string, blocks map[minecraft.Pos]string) (map[minecraft.Pos]string, error) {
+	d := world.Dimension(minecraft.NormalizeDimension(dimension))
+	drifted := map[minecraft.Pos]string{}
+	for p, want := range blocks {
+		got, err := d.Block(p.X, p.Y, p.Z)
+		if errors.Is(err, anvil.ErrNotGenerated) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !blockMatches(want, got) {
+			drifted[p] = got
+		}
+	}
+	return drifted, nil
+}
+
+func isAirBlock(id string) bool {
+	return id == "minecraft:air" || id == "minecraft:cave_air" || id == "minecraft:void_air"
+}
+
+// diffBlocks returns the blocks to write to get from old to new: changed and
+// added blocks, plus air for removed ones.
+func diffBlocks(old, new map[minecraft.Pos]string) map[minecraft.Pos]string {
+	changes := map[minecraft.Pos]string{}
+	for pos := range old {
+		if _, ok := new[pos]; !ok {
+			changes[pos] = "minecraft:air"
+		}
+	}
+	for pos, material := range new {
+		if old[pos] != material {
+			changes[pos] = material
+		}
+	}
+	return changes
+}
+
+func (r blocksResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data blocksResourceData
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blocks, err := parseBlockMap(data.Blocks)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, blocks); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place blocks, got error: %s", err))
+		return
+	}
+
+	id := "blocks-empty"
+	if positions := minecraft.SortedPositions(blocks); len(positions) > 0 {
+		p := positions[0]
+		id = fmt.Sprintf("blocks-%d-%d-%d", p.X, p.Y, p.Z)
+	}
+	data.Id = types.String{Value: id}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read checks the blocks against the world files when world_path is set, and
+// records any that changed in game so the plan puts them back. Without world
+// files it is a no-op: checking every block would cost one RCON round-trip
+// per block, which defeats the point of this resource.
+func (r blocksResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data blocksResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read blocks, got error: %s", err))
+		return
+	}
+	if world != nil {
+		keys := make(map[minecraft.Pos]string, len(data.Blocks))
+		blocks := make(map[minecraft.Pos]string, len(data.Blocks))
+		for key, block := range data.Blocks {
+			if p, err := minecraft.ParsePos(key); err == nil {
+				keys[p], blocks[p] = key, block
+			}
+		}
+		drifted, err := driftedBlocks(world, data.Dimension.Value, blocks)
+		if err != nil {
+			resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read blocks, got error: %s", err))
+			return
+		}
+		for p, got := range drifted {
+			data.Blocks[keys[p]] = got
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r blocksResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, prior blocksResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blocks, err := parseBlockMap(data.Blocks)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+	old, err := parseBlockMap(prior.Blocks)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, diffBlocks(old, blocks)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update blocks, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r blocksResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data blocksResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blocks, err := parseBlockMap(data.Blocks)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, diffBlocks(blocks, nil)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete blocks, got error: %s", err))
+		return
+	}
+}
+
+func (r blocksResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
+
+// blockPositionKeysValidator checks the keys of a block map are "x,y,z"
+// positions.
+type blockPositionKeysValidator struct{}
+
+func (v blockPositionKeysValidator) Description(ctx context.Context) string {
+	return "keys must be positions written as x,y,z"
+}
+
+func (v blockPositionKeysValidator) MarkdownDescription(ctx context.Context) string {
+	return "keys must be positions written as `x,y,z`"
+}
+
+func (v blockPositionKeysValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	blocks, ok := req.AttributeConfig.(types.Map)
+	if !ok || blocks.Null || blocks.Unknown {
+		return
+	}
+
+	values := make(map[string]string, len(blocks.Elems))
+	for key := range blocks.Elems {
+		values[key] = ""
+	}
+	if _, err := parseBlockMap(values); err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid Block Position", err.Error())
+	}
+}
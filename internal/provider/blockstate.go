@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Helpers shared by the resources that manage one kind of block with a fixed
+// set of block state properties (doors, trapdoors, fence gates, slabs).
+
+// boolState renders an optional boolean property; null means false.
+func boolState(b *bool) string {
+	return fmt.Sprintf("%t", b != nil && *b)
+}
+
+// stringState returns an optional string property or its default.
+func stringState(s types.String, def string) string {
+	if s.Null || s.Unknown || s.Value == "" {
+		return def
+	}
+	return s.Value
+}
+
+// readBoolState returns the value read from the server, keeping an unset
+// attribute null while the block is at the default of false.
+func readBoolState(current *bool, value string) *bool {
+	b := value == "true"
+	if current == nil && !b {
+		return nil
+	}
+	return &b
+}
+
+// readStringState returns the value read from the server, keeping an unset
+// attribute null while the block is at its default.
+func readStringState(current types.String, value, def string) types.String {
+	if current.Null && value == def {
+		return current
+	}
+	return types.String{Value: value}
+}
+
+// stateCandidates lists the values to probe for each property in state when
+// reading it back. Properties the registry doesn't know are probed for the
+// configured value only.
+func stateCandidates(registry minecraft.BlockStates, material string, state map[string]string) map[string][]string {
+	props := registry[minecraft.NormalizeMaterial(material)]
+	candidates := map[string][]string{}
+	for key, value := range state {
+		if values, ok := props[key]; ok {
+			candidates[key] = values
+		} else {
+			candidates[key] = []string{value}
+		}
+	}
+	return candidates
+}
+
+// checkBlockState validates material and its block state at plan time.
+// material must end in suffix, e.g. `_door`. Blocks missing from the
+// registry only get a warning so modded blocks still work.
+func checkBlockState(diags *diag.Diagnostics, registry minecraft.BlockStates, material, suffix string, state map[string]string) {
+	materialPath := tftypes.NewAttributePath().WithAttributeName("material")
+
+	if !strings.HasSuffix(material, suffix) {
+		diags.AddAttributeError(materialPath, "Validation Error", fmt.Sprintf("material must be a *%s block, got %q", suffix, material))
+		return
+	}
+
+	if err := registry.Validate(material, state); err != nil {
+		if errors.Is(err, minecraft.ErrUnknownBlock) {
+			diags.AddAttributeWarning(materialPath, "Unvalidated Block State", fmt.Sprintf("%s; block state properties are passed through unchecked.", err))
+			return
+		}
+		diags.AddError("Validation Error", err.Error())
+	}
+}
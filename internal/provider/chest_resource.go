@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
 )
 
 var _ tfsdk.ResourceType = chestResourceType{}
@@ -53,6 +54,63 @@ func (t chestResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Di
 				Optional:            true,
 				Type:                types.BoolType,
 			},
+			"facing": {
+				MarkdownDescription: "Direction the chest opens towards: one of `north`, `south`, `east`, `west`. Defaults to `north`. For a double chest the second half is placed one block clockwise of `facing`, e.g. one block east of `position` for a north-facing chest.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"custom_name": {
+				MarkdownDescription: "Name shown as the chest's inventory title.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"lock": {
+				MarkdownDescription: "Name an item must have to open the chest.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"loot_table": {
+				MarkdownDescription: "Loot table that fills the chest when it is first opened, e.g. `minecraft:chests/simple_dungeon`. Conflicts with `items`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"items": {
+				MarkdownDescription: "The chest contents. When set, the inventory is managed exactly: emptied or looted chests show as drift. Slots run from `0` to `26` (`53` for double chests, where slots `27`+ are in the second half).",
+				Optional:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"slot": {
+						MarkdownDescription: "Inventory slot.",
+						Required:            true,
+						Type:                types.NumberType,
+					},
+					"item": {
+						MarkdownDescription: "Item ID, e.g. `minecraft:diamond_sword`.",
+						Required:            true,
+						Type:                types.StringType,
+					},
+					"count": {
+						MarkdownDescription: "Stack size. Defaults to `1`.",
+						Optional:            true,
+						Type:                types.NumberType,
+					},
+					"enchantments": {
+						MarkdownDescription: "Enchantment levels keyed by enchantment ID, e.g. `{ \"minecraft:sharpness\" = 5 }`.",
+						Optional:            true,
+						Type:                types.MapType{ElemType: types.NumberType},
+					},
+					"custom_name": {
+						MarkdownDescription: "Custom item name.",
+						Optional:            true,
+						Type:                types.StringType,
+					},
+					"lore": {
+						MarkdownDescription: "Lore lines shown under the item name.",
+						Optional:            true,
+						Type:                types.ListType{ElemType: types.StringType},
+					},
+				}),
+			},
+			"dimension": dimensionAttribute(),
 			"id": {
 				Computed:            true,
 				MarkdownDescription: "ID of the chest resource.",
@@ -70,11 +128,26 @@ func (t chestResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (
 	return chestResource{provider: provider}, diags
 }
 
+type chestItemData struct {
+	Slot         int            `tfsdk:"slot"`
+	Item         string         `tfsdk:"item"`
+	Count        *int           `tfsdk:"count"`
+	Enchantments map[string]int `tfsdk:"enchantments"`
+	CustomName   types.String   `tfsdk:"custom_name"`
+	Lore         []string       `tfsdk:"lore"`
+}
+
 type chestResourceData struct {
-	Id          types.String `tfsdk:"id"`
-	Size        string       `tfsdk:"size"`
-	Trapped     *bool        `tfsdk:"trapped"`
-	Waterlogged *bool        `tfsdk:"waterlogged"`
+	Id          types.String    `tfsdk:"id"`
+	Dimension   types.String    `tfsdk:"dimension"`
+	Size        string          `tfsdk:"size"`
+	Trapped     *bool           `tfsdk:"trapped"`
+	Waterlogged *bool           `tfsdk:"waterlogged"`
+	Facing      types.String    `tfsdk:"facing"`
+	CustomName  types.String    `tfsdk:"custom_name"`
+	Lock        types.String    `tfsdk:"lock"`
+	LootTable   types.String    `tfsdk:"loot_table"`
+	Items       []chestItemData `tfsdk:"items"`
 	Position    struct {
 		X int `tfsdk:"x"`
 		Y int `tfsdk:"y"`
@@ -86,28 +159,40 @@ type chestResource struct {
 	provider provider
 }
 
-func (r chestResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
-	var data chestResourceData
-	diags := req.Config.Get(ctx, &data)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+// Clockwise turn of each horizontal direction.
+var clockwise = map[string]string{
+	"north": "east",
+	"east":  "south",
+	"south": "west",
+	"west":  "north",
+}
 
-	client, err := r.provider.GetClient(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
-		return
+func (d chestResourceData) facing() string {
+	if d.Facing.Null || d.Facing.Value == "" {
+		return "north"
 	}
+	return d.Facing.Value
+}
+
+// partner returns the position of the second half of a double chest. A
+// `type=left` chest joins the chest clockwise of its facing, i.e. to its
+// right when seen from the front.
+func (d chestResourceData) partner() (x, y, z int) {
+	dx, dz, _ := bedOffset(clockwise[d.facing()])
+	return d.Position.X + dx, d.Position.Y, d.Position.Z + dz
+}
 
+// blocks returns the chest block states for the first block and, for a
+// double chest, its partner.
+func (d chestResourceData) blocks() (first string, second string) {
 	waterlogged := false
-	if data.Waterlogged != nil {
-		waterlogged = *data.Waterlogged
+	if d.Waterlogged != nil {
+		waterlogged = *d.Waterlogged
 	}
 
 	trapped := false
-	if data.Trapped != nil {
-		trapped = *data.Trapped
+	if d.Trapped != nil {
+		trapped = *d.Trapped
 	}
 
 	material := "minecraft:chest"
@@ -115,30 +200,175 @@ func (r chestResource) Create(ctx context.Context, req tfsdk.CreateResourceReque
 		material = "minecraft:trapped_chest"
 	}
 
-	switch data.Size {
-	case "single":
-		block := fmt.Sprintf(`%s[type=single,waterlogged=%t]`, material, waterlogged)
-		err = client.CreateBlock(ctx, block, data.Position.X, data.Position.Y, data.Position.Z)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place single chest: %s", err))
-			return
+	state := func(kind string) string {
+		props := map[string]string{"type": kind, "facing": d.facing(), "waterlogged": fmt.Sprintf("%t", waterlogged)}
+		return minecraft.BlockWithState(material, props)
+	}
+
+	if d.Size == "double" {
+		return state("left"), state("right")
+	}
+	return state("single"), ""
+}
+
+// container returns the block entity data for one half of the chest; half 1
+// is the partner block of a double chest and holds slots 27 and up.
+func (d chestResourceData) container(half int) minecraft.Container {
+	c := minecraft.Container{
+		CustomName: d.CustomName.Value,
+		Lock:       d.Lock.Value,
+	}
+	if half == 0 {
+		c.LootTable = d.LootTable.Value
+	}
+	if d.Items != nil {
+		c.Items = []minecraft.ContainerItem{}
+		for _, item := range d.Items {
+			if item.Slot/minecraft.ChestSlots != half {
+				continue
+			}
+			count := 1
+			if item.Count != nil {
+				count = *item.Count
+			}
+			c.Items = append(c.Items, minecraft.ContainerItem{
+				Slot:         item.Slot % minecraft.ChestSlots,
+				ID:           item.Item,
+				Count:        count,
+				Enchantments: item.Enchantments,
+				CustomName:   item.CustomName.Value,
+				Lore:         item.Lore,
+			})
 		}
+	}
+	return c
+}
+
+// managesData reports whether any block entity attribute is set.
+func (d chestResourceData) managesData() bool {
+	return !d.CustomName.Null || !d.Lock.Null || !d.LootTable.Null || d.Items != nil
+}
+
+func (d chestResourceData) validate() error {
+	slots := minecraft.ChestSlots
+	switch d.Size {
+	case "single":
 	case "double":
-		blockLeft := fmt.Sprintf(`%s[type=left,waterlogged=%t]`, material, waterlogged)
-		blockRight := fmt.Sprintf(`%s[type=right,waterlogged=%t]`, material, waterlogged)
-		err = client.CreateBlock(ctx, blockLeft, data.Position.X, data.Position.Y, data.Position.Z)
+		slots *= 2
+	default:
+		return fmt.Errorf("size must be 'single' or 'double'")
+	}
+
+	if !d.Facing.Null {
+		if _, _, ok := bedOffset(d.Facing.Value); !ok {
+			return fmt.Errorf("facing must be one of north|south|east|west")
+		}
+	}
+
+	if d.Items != nil && !d.LootTable.Null {
+		return fmt.Errorf("items and loot_table cannot both be set")
+	}
+
+	used := map[int]bool{}
+	for _, item := range d.Items {
+		if item.Slot < 0 || item.Slot >= slots {
+			return fmt.Errorf("slot %d is out of range for a %s chest (0-%d)", item.Slot, d.Size, slots-1)
+		}
+		if used[item.Slot] {
+			return fmt.Errorf("slot %d is used more than once", item.Slot)
+		}
+		used[item.Slot] = true
+		if item.Count != nil && (*item.Count < 1 || *item.Count > 99) {
+			return fmt.Errorf("count for slot %d must be between 1 and 99", item.Slot)
+		}
+	}
+	return nil
+}
+
+// chestNBT renders container data, or nothing when there is none to manage.
+func chestNBT(version minecraft.Version, c minecraft.Container) string {
+	nbt := minecraft.ContainerNBT(version, c)
+	if nbt == "{}" {
+		return ""
+	}
+	return nbt
+}
+
+// placeChest places one or both halves with their block entity data.
+// setblock is a no-op when the block state is unchanged, so the data is also
+// merged explicitly. The hint is the chest block already there, or "" on
+// create.
+func placeChest(ctx context.Context, tx *minecraft.Transaction, version minecraft.Version, data chestResourceData, hint string) error {
+	first, second := data.blocks()
+	firstNBT := chestNBT(version, data.container(0))
+	if err := tx.SetBlock(ctx, hint, first+firstNBT, data.Position.X, data.Position.Y, data.Position.Z); err != nil {
+		return fmt.Errorf("first block: %w", err)
+	}
+	if firstNBT != "" {
+		if err := tx.MergeBlockData(ctx, first, firstNBT, data.Position.X, data.Position.Y, data.Position.Z); err != nil {
+			return fmt.Errorf("first block contents: %w", err)
+		}
+	}
+	if data.Size != "double" {
+		return nil
+	}
+
+	px, py, pz := data.partner()
+	secondNBT := chestNBT(version, data.container(1))
+	if err := tx.SetBlock(ctx, hint, second+secondNBT, px, py, pz); err != nil {
+		return fmt.Errorf("second block: %w", err)
+	}
+	if secondNBT != "" {
+		if err := tx.MergeBlockData(ctx, second, secondNBT, px, py, pz); err != nil {
+			return fmt.Errorf("second block contents: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r chestResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data chestResourceData
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.validate(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
+		return
+	}
+
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+
+	if data.Size == "double" {
+		px, py, pz := data.partner()
+		free, err := client.IsAir(ctx, px, py, pz)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place left half of double chest: %s", err))
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check right half of double chest: %s", err))
 			return
 		}
-		err = client.CreateBlock(ctx, blockRight, data.Position.X+1, data.Position.Y, data.Position.Z)
-		if err != nil {
-			_ = client.DeleteBlock(ctx, data.Position.X, data.Position.Y, data.Position.Z)
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place right half of double chest: %s", err))
+		if !free {
+			resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("The right half of a %s-facing double chest goes at %d %d %d, which is not free.", data.facing(), px, py, pz))
 			return
 		}
-	default:
-		resp.Diagnostics.AddError("Validation Error", "size must be 'single' or 'double'")
+	}
+
+	err = client.Transact(ctx, registry, func(tx *minecraft.Transaction) error {
+		return placeChest(ctx, tx, r.provider.ServerVersion(), data, "")
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place %s chest: %s", data.Size, err))
 		return
 	}
 
@@ -147,6 +377,9 @@ func (r chestResource) Create(ctx context.Context, req tfsdk.CreateResourceReque
 	resp.Diagnostics.Append(diags...)
 }
 
+// Read checks the chest is still there and, when contents or metadata are
+// managed, reads the block entity back so looted or renamed chests show as
+// drift.
 func (r chestResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
 	var data chestResourceData
 	diags := req.State.Get(ctx, &data)
@@ -154,62 +387,185 @@ func (r chestResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
+		return
+	}
+
+	first, _ := data.blocks()
+	exists, err := client.TestBlock(ctx, first, data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read chest: %s", err))
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if data.managesData() {
+		current, err := readChestContainer(ctx, client, data.Position.X, data.Position.Y, data.Position.Z)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read chest contents: %s", err))
+			return
+		}
+
+		if data.Size == "double" && data.Items != nil {
+			px, py, pz := data.partner()
+			partner, err := readChestContainer(ctx, client, px, py, pz)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read chest contents: %s", err))
+				return
+			}
+			for _, item := range partner.Items {
+				item.Slot += minecraft.ChestSlots
+				current.Items = append(current.Items, item)
+			}
+		}
+
+		data.readContainer(current)
+	}
+
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
+func readChestContainer(ctx context.Context, client *minecraft.Client, x, y, z int) (minecraft.Container, error) {
+	raw, err := client.GetBlockData(ctx, x, y, z)
+	if err != nil {
+		return minecraft.Container{}, err
+	}
+	return minecraft.ParseContainerNBT(raw)
+}
+
+// readContainer merges container data read from the server into state.
+// Unset attributes stay null, and items that match their configuration
+// (ignoring the implicit `minecraft:` namespace) keep the configured form.
+func (d *chestResourceData) readContainer(current minecraft.Container) {
+	if !d.CustomName.Null || current.CustomName != "" {
+		d.CustomName = types.String{Value: current.CustomName}
+	}
+	if !d.Lock.Null || current.Lock != "" {
+		d.Lock = types.String{Value: current.Lock}
+	}
+	if !d.LootTable.Null {
+		// The loot table is consumed when the chest is first opened.
+		d.LootTable = types.String{Value: current.LootTable, Null: current.LootTable == ""}
+	}
+
+	if d.Items == nil {
+		return
+	}
+
+	prior := map[int]chestItemData{}
+	for _, item := range d.Items {
+		prior[item.Slot] = item
+	}
+
+	items := make([]chestItemData, 0, len(current.Items))
+	for _, got := range current.Items {
+		if want, ok := prior[got.Slot]; ok && chestItemMatches(want, got) {
+			items = append(items, want)
+			continue
+		}
+
+		item := chestItemData{
+			Slot:         got.Slot,
+			Item:         got.ID,
+			Enchantments: got.Enchantments,
+			CustomName:   types.String{Value: got.CustomName, Null: got.CustomName == ""},
+			Lore:         got.Lore,
+		}
+		if got.Count != 1 {
+			count := got.Count
+			item.Count = &count
+		}
+		items = append(items, item)
+	}
+	d.Items = items
+}
+
+func chestItemMatches(want chestItemData, got minecraft.ContainerItem) bool {
+	count := 1
+	if want.Count != nil {
+		count = *want.Count
+	}
+	if minecraft.NormalizeMaterial(want.Item) != minecraft.NormalizeMaterial(got.ID) || count != got.Count {
+		return false
+	}
+	if want.CustomName.Value != got.CustomName || len(want.Lore) != len(got.Lore) || len(want.Enchantments) != len(got.Enchantments) {
+		return false
+	}
+	for i := range want.Lore {
+		if want.Lore[i] != got.Lore[i] {
+			return false
+		}
+	}
+	for id, lvl := range want.Enchantments {
+		if got.Enchantments[minecraft.NormalizeMaterial(id)] != lvl {
+			return false
+		}
+	}
+	return true
+}
+
 func (r chestResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
-	var data chestResourceData
+	var data, prior chestResourceData
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
+	if err := data.validate(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
 		return
 	}
 
-	waterlogged := false
-	if data.Waterlogged != nil {
-		waterlogged = *data.Waterlogged
-	}
-
-	trapped := false
-	if data.Trapped != nil {
-		trapped = *data.Trapped
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
+		return
 	}
 
-	material := "minecraft:chest"
-	if trapped {
-		material = "minecraft:trapped_chest"
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
 	}
 
-	switch data.Size {
-	case "single":
-		block := fmt.Sprintf(`%s[type=single,waterlogged=%t]`, material, waterlogged)
-		err = client.CreateBlock(ctx, block, data.Position.X, data.Position.Y, data.Position.Z)
+	// A new facing or size moves or drops the second half: make sure the new
+	// spot is free and clear the old one.
+	ox, oy, oz := prior.partner()
+	px, py, pz := data.partner()
+	moved := prior.Size != "double" || ox != px || oy != py || oz != pz
+	if data.Size == "double" && moved {
+		free, err := client.IsAir(ctx, px, py, pz)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update single chest: %s", err))
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check right half of double chest: %s", err))
 			return
 		}
-	case "double":
-		blockLeft := fmt.Sprintf(`%s[type=left,waterlogged=%t]`, material, waterlogged)
-		blockRight := fmt.Sprintf(`%s[type=right,waterlogged=%t]`, material, waterlogged)
-		err = client.CreateBlock(ctx, blockLeft, data.Position.X, data.Position.Y, data.Position.Z)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update left half of double chest: %s", err))
+		if !free {
+			resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("The right half of a %s-facing double chest goes at %d %d %d, which is not free.", data.facing(), px, py, pz))
 			return
 		}
-		err = client.CreateBlock(ctx, blockRight, data.Position.X+1, data.Position.Y, data.Position.Z)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update right half of double chest: %s", err))
-			return
+	}
+
+	err = client.Transact(ctx, registry, func(tx *minecraft.Transaction) error {
+		if prior.Size == "double" && (data.Size != "double" || moved) {
+			_, oldRight := prior.blocks()
+			if err := tx.DeleteBlock(ctx, oldRight, ox, oy, oz); err != nil {
+				return fmt.Errorf("unable to remove old right half: %w", err)
+			}
 		}
-	default:
-		resp.Diagnostics.AddError("Validation Error", "size must be 'single' or 'double'")
+		oldLeft, _ := prior.blocks()
+		return placeChest(ctx, tx, r.provider.ServerVersion(), data, oldLeft)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update %s chest: %s", data.Size, err))
 		return
 	}
 
@@ -225,7 +581,7 @@ func (r chestResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReque
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
 		return
@@ -233,7 +589,8 @@ func (r chestResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReque
 
 	_ = client.DeleteBlock(ctx, data.Position.X, data.Position.Y, data.Position.Z)
 	if data.Size == "double" {
-		_ = client.DeleteBlock(ctx, data.Position.X+1, data.Position.Y, data.Position.Z)
+		px, py, pz := data.partner()
+		_ = client.DeleteBlock(ctx, px, py, pz)
 	}
 }
 
@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = cloneResourceType{}
+var _ tfsdk.Resource = cloneResource{}
+var _ tfsdk.ResourceWithImportState = cloneResource{}
+var _ tfsdk.ResourceWithModifyPlan = cloneResource{}
+
+type cloneResourceType struct{}
+
+// fixedPosition is a required position attribute whose change replaces the
+// resource.
+func fixedPosition(description string) tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: description,
+		Required:            true,
+		Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+			"x": {
+				MarkdownDescription: "X coordinate.",
+				Type:                types.NumberType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"y": {
+				MarkdownDescription: "Y coordinate.",
+				Type:                types.NumberType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"z": {
+				MarkdownDescription: "Z coordinate.",
+				Type:                types.NumberType,
+				Required:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+		}),
+	}
+}
+
+func (t cloneResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	sourceDimension := dimensionAttribute()
+	sourceDimension.MarkdownDescription = "The dimension to copy from, e.g. `minecraft:the_nether`. Defaults to the overworld. Cloning between dimensions needs Minecraft 1.20.2 or later."
+	dimension := dimensionAttribute()
+	dimension.MarkdownDescription = "The dimension to copy to. Defaults to the overworld."
+
+	return tfsdk.Schema{
+		MarkdownDescription: "Copy a **cuboid region** to another place, block entities included (wraps `/clone`).",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"source_start": fixedPosition("Inclusive start corner of the area to copy."),
+			"source_end":   fixedPosition("Inclusive end corner of the area to copy."),
+			"destination":  fixedPosition("Where the lowest north-west corner of the source area lands."),
+			"mask_mode": {
+				MarkdownDescription: "Which source blocks to copy: `replace` (all), `masked` (all but air) or `filtered` (only those matching `filter`). Defaults to `replace`.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"filter": {
+				MarkdownDescription: "Block predicate for the `filtered` mask mode, e.g. `minecraft:stone_bricks` or `#minecraft:logs`.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"clone_mode": {
+				MarkdownDescription: "`normal`, `force` (allow the areas to overlap) or `move` (clear the source afterwards). Defaults to `normal`.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"source_dimension": sourceDimension,
+			"dimension":        dimension,
+			"block_count": {
+				MarkdownDescription: "Number of blocks in the source area.",
+				Computed:            true,
+				Type:                types.Int64Type,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the clone.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t cloneResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return cloneResource{provider: provider}, diags
+}
+
+type cloneResourceData struct {
+	Id              types.String `tfsdk:"id"`
+	Dimension       types.String `tfsdk:"dimension"`
+	SourceDimension types.String `tfsdk:"source_dimension"`
+	SourceStart     struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"source_start"`
+	SourceEnd struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"source_end"`
+	Destination struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"destination"`
+	MaskMode   types.String `tfsdk:"mask_mode"`
+	Filter     types.String `tfsdk:"filter"`
+	CloneMode  types.String `tfsdk:"clone_mode"`
+	BlockCount types.Int64  `tfsdk:"block_count"`
+}
+
+func (d cloneResourceData) clone() minecraft.Clone {
+	c := minecraft.Clone{
+		Source: minecraft.NewBox(
+			minecraft.Pos{X: d.SourceStart.X, Y: d.SourceStart.Y, Z: d.SourceStart.Z},
+			minecraft.Pos{X: d.SourceEnd.X, Y: d.SourceEnd.Y, Z: d.SourceEnd.Z},
+		),
+		SourceDimension:      cloneDimension(d.SourceDimension),
+		Destination:          minecraft.Pos{X: d.Destination.X, Y: d.Destination.Y, Z: d.Destination.Z},
+		DestinationDimension: cloneDimension(d.Dimension),
+		Mask:                 "replace",
+		Filter:               d.Filter.Value,
+		Mode:                 "normal",
+	}
+	if d.MaskMode.Value != "" {
+		c.Mask = d.MaskMode.Value
+	}
+	if d.CloneMode.Value != "" {
+		c.Mode = d.CloneMode.Value
+	}
+	return c
+}
+
+// cloneDimension normalises a dimension, with the overworld as "".
+func cloneDimension(dimension types.String) string {
+	d := minecraft.NormalizeDimension(dimension.Value)
+	if d == "minecraft:overworld" {
+		return ""
+	}
+	return d
+}
+
+type cloneResource struct {
+	provider provider
+}
+
+func (r cloneResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data cloneResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Dimensions are part of the clone command, so use a plain client.
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	clone := data.clone()
+	if _, err := client.Clone(ctx, r.provider.ServerVersion(), clone); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clone region, got error: %s", err))
+		return
+	}
+
+	data.Id = types.String{Value: fmt.Sprintf("clone-%d-%d-%d", data.Destination.X, data.Destination.Y, data.Destination.Z)}
+	data.BlockCount = types.Int64{Value: int64(clone.Source.Volume())}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read is a no-op: the clone is a one-off copy, and the destination is
+// expected to change in game afterwards.
+func (r cloneResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data cloneResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update has nothing to do: every argument replaces the clone.
+func (r cloneResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data cloneResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete clears the destination area of a `replace` clone. Masked and
+// filtered clones only wrote some of the destination, and what was there
+// before is not recorded, so they are left in place. A moved source is not
+// put back.
+func (r cloneResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data cloneResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clone := data.clone()
+	if clone.Mask != "replace" {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	destination := clone.DestinationBox()
+	destination.Block = "minecraft:air"
+	for _, box := range minecraft.SplitBox(destination) {
+		if err := client.FillBox(ctx, box); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clear cloned region, got error: %s", err))
+			return
+		}
+	}
+}
+
+// ModifyPlan checks the modes, overlap and dimension support before
+// anything is sent to the server.
+func (r cloneResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data cloneResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clone := data.clone()
+	if err := clone.Validate(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+	version := r.provider.ServerVersion()
+	if clone.SourceDimension != clone.DestinationDimension && !minecraft.CloneSupportsDimensions(version) {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("source_dimension"), "Unsupported Clone",
+			fmt.Sprintf("Cloning between dimensions needs Minecraft 1.20.2 or later; the server is %s.", version))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("block_count"), types.Int64{Value: int64(clone.Source.Volume())})...)
+}
+
+func (r cloneResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
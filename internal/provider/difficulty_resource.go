@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = difficultyResourceType{}
+var _ tfsdk.Resource = difficultyResource{}
+var _ tfsdk.ResourceWithImportState = difficultyResource{}
+var _ tfsdk.ResourceWithModifyPlan = difficultyResource{}
+
+type difficultyResourceType struct{}
+
+func (t difficultyResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Set the server's **difficulty** (wraps `/difficulty`). The previous difficulty is put back on destroy.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"difficulty": {
+				MarkdownDescription: "Difficulty to set: `peaceful`, `easy`, `normal` or `hard`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"previous_difficulty": {
+				MarkdownDescription: "Best-effort snapshot of the difficulty before it was first set, put back on destroy.",
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+			"hardcore": {
+				MarkdownDescription: "Whether the world is hardcore, which fixes the difficulty at `hard`. Hardcore is chosen when the world is created and cannot be changed. Read from `level.dat` when `world_path` is set, otherwise null.",
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.BoolType,
+			},
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the difficulty, always `default`.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t difficultyResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return difficultyResource{provider: provider}, diags
+}
+
+type difficultyResourceData struct {
+	Id                 types.String `tfsdk:"id"`
+	Difficulty         string       `tfsdk:"difficulty"`
+	PreviousDifficulty types.String `tfsdk:"previous_difficulty"`
+	Hardcore           types.Bool   `tfsdk:"hardcore"`
+}
+
+type difficultyResource struct {
+	provider provider
+}
+
+// hardcore reads the hardcore flag from the world files, or returns null
+// when world_path is not set.
+func (r difficultyResource) hardcore(ctx context.Context) (types.Bool, error) {
+	world, err := r.provider.World(ctx)
+	if err != nil || world == nil {
+		return types.Bool{Null: true}, err
+	}
+	level, err := world.Level()
+	if err != nil {
+		return types.Bool{Null: true}, err
+	}
+	return types.Bool{Value: minecraft.HardcoreFromLevel(level)}, nil
+}
+
+// setDifficulty sets the difficulty and reads it back, since the server may
+// keep the old one, e.g. in a hardcore world.
+func setDifficulty(ctx context.Context, client *minecraft.Client, difficulty string) error {
+	if err := client.SetDifficulty(ctx, difficulty); err != nil {
+		return err
+	}
+	current, err := client.GetDifficulty(ctx)
+	if err != nil {
+		return err
+	}
+	if current != difficulty {
+		return fmt.Errorf("asked for %s, but the difficulty is still %s", difficulty, current)
+	}
+	return nil
+}
+
+func (r difficultyResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data difficultyResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	// Snapshot the previous difficulty (best effort)
+	previous, err := client.GetDifficulty(ctx)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Snapshot Warning", fmt.Sprintf("Unable to read the current difficulty, so it will not be restored on destroy: %s", err))
+	}
+	data.PreviousDifficulty = types.String{Value: previous}
+
+	if err := setDifficulty(ctx, client, data.Difficulty); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set difficulty, got error: %s", err))
+		return
+	}
+
+	data.Hardcore, err = r.hardcore(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read level.dat, got error: %s", err))
+		return
+	}
+
+	data.Id = types.String{Value: "default"}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r difficultyResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data difficultyResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	difficulty, err := client.GetDifficulty(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read difficulty, got error: %s", err))
+		return
+	}
+	data.Difficulty = difficulty
+
+	data.Hardcore, err = r.hardcore(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read level.dat, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r difficultyResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data difficultyResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if err := setDifficulty(ctx, client, data.Difficulty); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set difficulty, got error: %s", err))
+		return
+	}
+
+	// Unknown when it was not read before, e.g. world_path was just set.
+	if data.Hardcore.Unknown {
+		data.Hardcore, err = r.hardcore(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read level.dat, got error: %s", err))
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete puts back the difficulty recorded before the first apply.
+func (r difficultyResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data difficultyResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous := data.PreviousDifficulty.Value
+	if previous == "" {
+		return
+	}
+
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+	if err := client.SetDifficulty(ctx, previous); err != nil {
+		resp.Diagnostics.AddWarning("Restore Warning", fmt.Sprintf("Failed to restore difficulty to %q: %s", previous, err))
+	}
+}
+
+// ModifyPlan checks the difficulty, and that a hardcore world stays on hard.
+func (r difficultyResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data difficultyResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	path := tftypes.NewAttributePath().WithAttributeName("difficulty")
+	if err := minecraft.ValidateDifficulty(data.Difficulty); err != nil {
+		resp.Diagnostics.AddAttributeError(path, "Validation Error", err.Error())
+		return
+	}
+
+	// Read the flag for a new resource, or one whose state lacks it.
+	hardcore := types.Bool{Null: true}
+	if !req.State.Raw.IsNull() {
+		var state difficultyResourceData
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		hardcore = state.Hardcore
+	}
+	if hardcore.Null || hardcore.Unknown {
+		var err error
+		hardcore, err = r.hardcore(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read level.dat, got error: %s", err))
+			return
+		}
+	}
+	if hardcore.Value && data.Difficulty != "hard" {
+		resp.Diagnostics.AddAttributeError(path, "Validation Error",
+			fmt.Sprintf("The world is hardcore, so its difficulty is fixed at hard (got %q).", data.Difficulty))
+	}
+}
+
+// ImportState adopts the current difficulty. Nothing is recorded to restore
+// on destroy.
+func (r difficultyResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	difficulty, err := client.GetDifficulty(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to read difficulty, got error: %s", err))
+		return
+	}
+	hardcore, err := r.hardcore(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to read level.dat, got error: %s", err))
+		return
+	}
+
+	data := difficultyResourceData{
+		Id:                 types.String{Value: "default"},
+		Difficulty:         difficulty,
+		PreviousDifficulty: types.String{Value: ""},
+		Hardcore:           hardcore,
+	}
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// dimensionAttribute is the optional `dimension` attribute shared by every
+// resource that edits blocks or summons entities.
+func dimensionAttribute() tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: "The dimension to run in, e.g. `minecraft:the_nether`, `minecraft:the_end` or a datapack dimension such as `mypack:arena`. Defaults to the overworld.",
+		Optional:            true,
+		Type:                types.StringType,
+		Validators: []tfsdk.AttributeValidator{
+			dimensionValidator{},
+		},
+		PlanModifiers: tfsdk.AttributePlanModifiers{
+			tfsdk.RequiresReplace(), // moving to another dimension => new resource
+		},
+	}
+}
+
+type dimensionValidator struct{}
+
+func (v dimensionValidator) Description(ctx context.Context) string {
+	return "value must be a vanilla dimension or a namespaced datapack dimension"
+}
+
+func (v dimensionValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be `minecraft:overworld`, `minecraft:the_nether`, `minecraft:the_end` or a namespaced datapack dimension"
+}
+
+func (v dimensionValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var dimension types.String
+	resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &dimension)...)
+	if resp.Diagnostics.HasError() || dimension.Null || dimension.Unknown {
+		return
+	}
+
+	if err := minecraft.ValidateDimension(dimension.Value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid Dimension", err.Error())
+	}
+}
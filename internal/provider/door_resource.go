@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = doorResourceType{}
+var _ tfsdk.Resource = doorResource{}
+var _ tfsdk.ResourceWithImportState = doorResource{}
+var _ tfsdk.ResourceWithModifyPlan = doorResource{}
+
+type doorResourceType struct{}
+
+func (t doorResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "A Minecraft door (two-block structure). The position is the LOWER half; the UPPER half goes one block above.",
+		Attributes: map[string]tfsdk.Attribute{
+			"material": {
+				MarkdownDescription: "The door material, e.g. `minecraft:oak_door`, `minecraft:iron_door`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"position": {
+				MarkdownDescription: "The LOWER position of the door.",
+				Required:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"x": {
+						MarkdownDescription: "X coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+					"y": {
+						MarkdownDescription: "Y coordinate (lower half)",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+					"z": {
+						MarkdownDescription: "Z coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+				}),
+			},
+			"facing": {
+				MarkdownDescription: "Direction the door faces when closed: one of `north`, `south`, `east`, `west`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"hinge": {
+				MarkdownDescription: "Side of the hinge as seen when facing the same way as the door: `left` or `right`. Defaults to `left`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"open": {
+				MarkdownDescription: "Whether the door is open. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"powered": {
+				MarkdownDescription: "Whether the door is powered by redstone. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the door resource.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t doorResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return doorResource{provider: provider}, diags
+}
+
+type doorResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Material  string       `tfsdk:"material"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+	Facing  string       `tfsdk:"facing"`  // north|south|east|west
+	Hinge   types.String `tfsdk:"hinge"`   // left|right
+	Open    *bool        `tfsdk:"open"`    // optional
+	Powered *bool        `tfsdk:"powered"` // optional
+}
+
+// state returns the block state shared by both halves, without `half`.
+func (d doorResourceData) state() map[string]string {
+	return map[string]string{
+		"facing":  d.Facing,
+		"hinge":   stringState(d.Hinge, "left"),
+		"open":    boolState(d.Open),
+		"powered": boolState(d.Powered),
+	}
+}
+
+// half renders the block state of the lower or upper half.
+func (d doorResourceData) half(half string) string {
+	state := d.state()
+	state["half"] = half
+	return minecraft.BlockWithState(d.Material, state)
+}
+
+type doorResource struct {
+	provider provider
+}
+
+// placeDoor places both halves in one transaction, lower first, and checks
+// they stuck.
+func placeDoor(ctx context.Context, tx *minecraft.Transaction, client *minecraft.Client, data doorResourceData, hint string) error {
+	x, y, z := data.Position.X, data.Position.Y, data.Position.Z
+	if err := tx.SetBlock(ctx, hint, data.half("lower"), x, y, z); err != nil {
+		return err
+	}
+	if err := tx.SetBlock(ctx, hint, data.half("upper"), x, y+1, z); err != nil {
+		return err
+	}
+
+	ok, err := doorInPlace(ctx, client, data)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("the door did not stay in place at %d %d %d", x, y, z)
+	}
+	return nil
+}
+
+// doorInPlace reports whether both halves match the material and facing.
+func doorInPlace(ctx context.Context, client *minecraft.Client, data doorResourceData) (bool, error) {
+	for i, half := range []string{"lower", "upper"} {
+		block := minecraft.BlockWithState(data.Material, map[string]string{"facing": data.Facing, "half": half})
+		ok, err := client.TestBlock(ctx, block, data.Position.X, data.Position.Y+i, data.Position.Z)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (r doorResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data doorResourceData
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+
+	free, err := client.IsAir(ctx, data.Position.X, data.Position.Y+1, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check upper half of door, got error: %s", err))
+		return
+	}
+	if !free {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("The upper half of the door goes at %d %d %d, which is not free.", data.Position.X, data.Position.Y+1, data.Position.Z))
+		return
+	}
+
+	err = client.Transact(ctx, registry, func(tx *minecraft.Transaction) error {
+		return placeDoor(ctx, tx, client, data, "")
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place door, got error: %s", err))
+		return
+	}
+
+	data.Id = types.String{Value: fmt.Sprintf("door-%d-%d-%d", data.Position.X, data.Position.Y, data.Position.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read checks both halves are still there and reads hinge, open and powered
+// back from the lower half.
+func (r doorResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data doorResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	ok, err := doorInPlace(ctx, client, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read door, got error: %s", err))
+		return
+	}
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	registry, _ := r.provider.BlockStates()
+	state := data.state()
+	delete(state, "facing")
+	current, err := client.GetBlockState(ctx, data.Material, stateCandidates(registry, data.Material, state), data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read door state, got error: %s", err))
+		return
+	}
+	data.Hinge = readStringState(data.Hinge, current["hinge"], "left")
+	data.Open = readBoolState(data.Open, current["open"])
+	data.Powered = readBoolState(data.Powered, current["powered"])
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r doorResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, prior doorResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+
+	err = client.Transact(ctx, registry, func(tx *minecraft.Transaction) error {
+		return placeDoor(ctx, tx, client, data, prior.Material)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update door, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r doorResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data doorResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	_ = client.DeleteBlock(ctx, data.Position.X, data.Position.Y+1, data.Position.Z)
+	_ = client.DeleteBlock(ctx, data.Position.X, data.Position.Y, data.Position.Z)
+}
+
+// ModifyPlan checks the material and block state against the registry.
+func (r doorResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data doorResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+
+	state := data.state()
+	state["half"] = "lower"
+	checkBlockState(&resp.Diagnostics, registry, minecraft.NormalizeMaterial(data.Material), "_door", state)
+}
+
+func (r doorResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
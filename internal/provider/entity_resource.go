@@ -61,6 +61,7 @@ func (t entityResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.D
 					},
 				}),
 			},
+			"dimension": dimensionAttribute(),
 			"id": {
 				Computed:            true,
 				MarkdownDescription: "UUID for this entity (also embedded as the entity's CustomName/tag).",
@@ -79,9 +80,10 @@ func (t entityResourceType) NewResource(ctx context.Context, in tfsdk.Provider)
 }
 
 type entityResourceData struct {
-	Id       types.String `tfsdk:"id"`
-	Type     string       `tfsdk:"type"`
-	Position struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Type      string       `tfsdk:"type"`
+	Position  struct {
 		X int `tfsdk:"x"`
 		Y int `tfsdk:"y"`
 		Z int `tfsdk:"z"`
@@ -100,7 +102,7 @@ func (r entityResource) Create(ctx context.Context, req tfsdk.CreateResourceRequ
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
 		return
@@ -155,7 +157,7 @@ func (r entityResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequ
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
 		return
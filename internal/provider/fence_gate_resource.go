@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = fenceGateResourceType{}
+var _ tfsdk.Resource = fenceGateResource{}
+var _ tfsdk.ResourceWithImportState = fenceGateResource{}
+var _ tfsdk.ResourceWithModifyPlan = fenceGateResource{}
+
+type fenceGateResourceType struct{}
+
+func (t fenceGateResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "A Minecraft fence gate (e.g., minecraft:oak_fence_gate). `in_wall` is worked out from the neighbouring blocks unless set.",
+		Attributes: map[string]tfsdk.Attribute{
+			"material": {
+				MarkdownDescription: "The fence gate material, e.g. `minecraft:oak_fence_gate`, `minecraft:crimson_fence_gate`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"position": {
+				MarkdownDescription: "The position of the fence gate.",
+				Required:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"x": {
+						MarkdownDescription: "X coordinate of the block",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+					"y": {
+						MarkdownDescription: "Y coordinate of the block",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+					"z": {
+						MarkdownDescription: "Z coordinate of the block",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+				}),
+			},
+			"facing": {
+				MarkdownDescription: "Direction the fence gate faces: one of `north`, `south`, `east`, `west`. The gate spans the two neighbours to its sides.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"open": {
+				MarkdownDescription: "Whether the fence gate is open. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"powered": {
+				MarkdownDescription: "Whether the fence gate is powered by redstone. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"in_wall": {
+				MarkdownDescription: "Whether the gate is lowered to line up with walls. Computed from the neighbours on either side of the gate (true when either is a wall) unless set.",
+				Optional:            true,
+				Computed:            true,
+				Type:                types.BoolType,
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the block",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t fenceGateResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return fenceGateResource{provider: provider}, diags
+}
+
+type fenceGateResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Material  string       `tfsdk:"material"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+	Facing  string     `tfsdk:"facing"`  // north|south|east|west
+	Open    *bool      `tfsdk:"open"`    // optional
+	Powered *bool      `tfsdk:"powered"` // optional
+	InWall  types.Bool `tfsdk:"in_wall"` // computed from neighbours unless set
+}
+
+func (d fenceGateResourceData) state() map[string]string {
+	return map[string]string{
+		"facing":  d.Facing,
+		"in_wall": fmt.Sprintf("%t", d.InWall.Value),
+		"open":    boolState(d.Open),
+		"powered": boolState(d.Powered),
+	}
+}
+
+// sides returns the positions of the two neighbours the gate spans between.
+func (d fenceGateResourceData) sides() [2][3]int {
+	dx, dz, _ := bedOffset(clockwise[d.Facing])
+	x, y, z := d.Position.X, d.Position.Y, d.Position.Z
+	return [2][3]int{{x + dx, y, z + dz}, {x - dx, y, z - dz}}
+}
+
+// resolveInWall works out in_wall the way the game does when a player
+// places the gate: it is lowered when either side is a wall.
+func (d *fenceGateResourceData) resolveInWall(ctx context.Context, client *minecraft.Client) error {
+	if !d.InWall.Null && !d.InWall.Unknown {
+		return nil
+	}
+	d.InWall = types.Bool{Value: false}
+	for _, side := range d.sides() {
+		wall, err := client.TestBlock(ctx, "#minecraft:walls", side[0], side[1], side[2])
+		if err != nil {
+			return err
+		}
+		if wall {
+			d.InWall = types.Bool{Value: true}
+			return nil
+		}
+	}
+	return nil
+}
+
+type fenceGateResource struct {
+	provider provider
+}
+
+func (r fenceGateResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data fenceGateResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if err := data.resolveInWall(ctx, client); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check fence gate neighbours, got error: %s", err))
+		return
+	}
+
+	err = client.CreateBlock(ctx, minecraft.BlockWithState(data.Material, data.state()), data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create fence gate, got error: %s", err))
+		return
+	}
+
+	data.Id = types.String{Value: fmt.Sprintf("fence_gate-%d-%d-%d", data.Position.X, data.Position.Y, data.Position.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r fenceGateResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data fenceGateResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	exists, err := client.TestBlock(ctx, data.Material, data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read fence gate, got error: %s", err))
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	registry, _ := r.provider.BlockStates()
+	current, err := client.GetBlockState(ctx, data.Material, stateCandidates(registry, data.Material, data.state()), data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read fence gate state, got error: %s", err))
+		return
+	}
+	if facing, ok := current["facing"]; ok {
+		data.Facing = facing
+	}
+	data.Open = readBoolState(data.Open, current["open"])
+	data.Powered = readBoolState(data.Powered, current["powered"])
+	if inWall, ok := current["in_wall"]; ok {
+		data.InWall = types.Bool{Value: inWall == "true"}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r fenceGateResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data fenceGateResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if err := data.resolveInWall(ctx, client); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check fence gate neighbours, got error: %s", err))
+		return
+	}
+
+	err = client.CreateBlock(ctx, minecraft.BlockWithState(data.Material, data.state()), data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update fence gate, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r fenceGateResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data fenceGateResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	err = client.DeleteBlock(ctx, data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete block, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan checks the material and block state against the registry.
+func (r fenceGateResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	// Validate the configuration: in_wall is still unknown in the plan.
+	var data fenceGateResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+
+	checkBlockState(&resp.Diagnostics, registry, minecraft.NormalizeMaterial(data.Material), "_fence_gate", data.state())
+}
+
+func (r fenceGateResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+)
+
+// fileHash returns the hex SHA-256 of a file. Resources built from local
+// files keep it in state so editing the file shows up as a change.
+func fileHash(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -91,6 +91,7 @@ func (t fillResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Dia
 				}),
 			},
 
+			"dimension": dimensionAttribute(),
 			"id": {
 				Computed:            true,
 				Type:                types.StringType,
@@ -109,9 +110,10 @@ func (t fillResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (t
 }
 
 type fillResourceData struct {
-	Id       types.String `tfsdk:"id"`
-	Material string       `tfsdk:"material"`
-	Start    struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Material  string       `tfsdk:"material"`
+	Start     struct {
 		X int `tfsdk:"x"`
 		Y int `tfsdk:"y"`
 		Z int `tfsdk:"z"`
@@ -135,7 +137,7 @@ func (r fillResource) Create(ctx context.Context, req tfsdk.CreateResourceReques
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
 		return
@@ -182,7 +184,7 @@ func (r fillResource) Update(ctx context.Context, req tfsdk.UpdateResourceReques
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
 		return
@@ -218,7 +220,7 @@ func (r fillResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReques
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client: %s", err))
 		return
@@ -0,0 +1,412 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = imageResourceType{}
+var _ tfsdk.Resource = imageResource{}
+var _ tfsdk.ResourceWithImportState = imageResource{}
+var _ tfsdk.ResourceWithModifyPlan = imageResource{}
+
+type imageResourceType struct{}
+
+func (t imageResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Pixel art: a PNG or JPEG image drawn as a wall or floor of coloured blocks.",
+		Attributes: map[string]tfsdk.Attribute{
+			"path": {
+				MarkdownDescription: "Path to a local PNG or JPEG file.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"position": {
+				MarkdownDescription: "Where the top-left pixel of the image goes.",
+				Required:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"x": {
+						MarkdownDescription: "X coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"y": {
+						MarkdownDescription: "Y coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"z": {
+						MarkdownDescription: "Z coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+				}),
+			},
+			"orientation": {
+				MarkdownDescription: "`north`, `south`, `east` or `west` for an upright wall seen from that side, or `floor` for a floor read with north at the top. Defaults to `south`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"width": {
+				MarkdownDescription: "Width in blocks. When only one of `width` and `height` is set the other keeps the aspect ratio; when neither is, the image is drawn one block per pixel. At most 65536 blocks in all.",
+				Optional:            true,
+				Computed:            true,
+				Type:                types.Int64Type,
+			},
+			"height": {
+				MarkdownDescription: "Height in blocks. See `width`.",
+				Optional:            true,
+				Computed:            true,
+				Type:                types.Int64Type,
+			},
+			"palette": {
+				MarkdownDescription: "Blocks to draw with: `wool`, `concrete` or `terracotta`. Ignored when `custom_palette` is set. Defaults to `wool`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"custom_palette": {
+				MarkdownDescription: "Blocks to draw with, mapped to their colour as `#rrggbb`, e.g. `{ \"minecraft:gold_block\" = \"#f6d03d\" }`.",
+				Optional:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+			},
+			"dither": {
+				MarkdownDescription: "Use Floyd–Steinberg dithering to approximate colours the palette lacks. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"source_hash": {
+				MarkdownDescription: "SHA-256 of the image file, so editing the file triggers an update. When `world_path` is set, refresh clears it if blocks of the drawing were changed in game.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the image resource.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t imageResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return imageResource{provider: provider}, diags
+}
+
+type imageResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Path      string       `tfsdk:"path"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+	Orientation   types.String      `tfsdk:"orientation"` // north|south|east|west|floor
+	Width         types.Int64       `tfsdk:"width"`
+	Height        types.Int64       `tfsdk:"height"`
+	Palette       types.String      `tfsdk:"palette"`
+	CustomPalette map[string]string `tfsdk:"custom_palette"`
+	Dither        *bool             `tfsdk:"dither"`
+	SourceHash    types.String      `tfsdk:"source_hash"`
+}
+
+// place maps pixel (x, y), counted from the top-left, to a world position.
+func (d imageResourceData) place(x, y int) minecraft.Pos {
+	o := minecraft.Pos{X: d.Position.X, Y: d.Position.Y, Z: d.Position.Z}
+	switch d.Orientation.Value {
+	case "north":
+		return minecraft.Pos{X: o.X - x, Y: o.Y - y, Z: o.Z}
+	case "east":
+		return minecraft.Pos{X: o.X, Y: o.Y - y, Z: o.Z - x}
+	case "west":
+		return minecraft.Pos{X: o.X, Y: o.Y - y, Z: o.Z + x}
+	case "floor":
+		return minecraft.Pos{X: o.X + x, Y: o.Y, Z: o.Z + y}
+	default: // south
+		return minecraft.Pos{X: o.X + x, Y: o.Y - y, Z: o.Z}
+	}
+}
+
+func (d imageResourceData) validate() error {
+	switch d.Orientation.Value {
+	case "", "north", "south", "east", "west", "floor":
+	default:
+		return fmt.Errorf("orientation must be one of north, south, east, west, floor (got %q)", d.Orientation.Value)
+	}
+	if (!d.Width.Null && !d.Width.Unknown && d.Width.Value < 1) || (!d.Height.Null && !d.Height.Unknown && d.Height.Value < 1) {
+		return fmt.Errorf("width and height must be at least 1")
+	}
+	_, err := d.palette()
+	return err
+}
+
+func (d imageResourceData) palette() (minecraft.Palette, error) {
+	return colorPalette(d.Palette, d.CustomPalette)
+}
+
+// colorPalette resolves the `palette` and `custom_palette` attributes shared
+// by resources that match colours to blocks.
+func colorPalette(name types.String, custom map[string]string) (minecraft.Palette, error) {
+	if custom != nil {
+		return minecraft.CustomPalette(custom)
+	}
+	if name.Null || name.Value == "" {
+		return minecraft.BuiltinPalette("wool")
+	}
+	return minecraft.BuiltinPalette(name.Value)
+}
+
+// maxImageBlocks is the most blocks an image may be drawn with. Every block
+// may take its own command, and the rendered image is kept in memory.
+const maxImageBlocks = 256 * 256
+
+// size resolves width and height against the image.
+func (d *imageResourceData) size() error {
+	img, err := minecraft.LoadImage(d.Path)
+	if err != nil {
+		return err
+	}
+	var w, h int
+	if !d.Width.Null && !d.Width.Unknown {
+		w = int(d.Width.Value)
+	}
+	if !d.Height.Null && !d.Height.Unknown {
+		h = int(d.Height.Value)
+	}
+	w, h = minecraft.ScaledSize(img, w, h)
+	if w*h > maxImageBlocks {
+		return fmt.Errorf("the image would be %dx%d blocks; set width or height so it is at most %d blocks in all", w, h, maxImageBlocks)
+	}
+	d.Width = types.Int64{Value: int64(w)}
+	d.Height = types.Int64{Value: int64(h)}
+	return nil
+}
+
+// blocks renders the image into world blocks. Width and height must be known.
+func (d imageResourceData) blocks() (map[minecraft.Pos]string, error) {
+	palette, err := d.palette()
+	if err != nil {
+		return nil, err
+	}
+	img, err := minecraft.LoadImage(d.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := minecraft.Quantize(minecraft.Resample(img, int(d.Width.Value), int(d.Height.Value)), palette, d.Dither != nil && *d.Dither)
+	blocks := map[minecraft.Pos]string{}
+	for y, row := range rows {
+		for x, block := range row {
+			if block != "" {
+				blocks[d.place(x, y)] = block
+			}
+		}
+	}
+	return blocks, nil
+}
+
+// placed returns the blocks this state put in the world. If the image file
+// has changed since, the exact pixels are gone, so the whole rectangle is
+// returned with unknown ("") blocks.
+func (d imageResourceData) placed() map[minecraft.Pos]string {
+	if hash, err := fileHash(d.Path); err == nil && hash == d.SourceHash.Value {
+		if blocks, err := d.blocks(); err == nil {
+			return blocks
+		}
+	}
+
+	blocks := map[minecraft.Pos]string{}
+	for y := 0; y < int(d.Height.Value); y++ {
+		for x := 0; x < int(d.Width.Value); x++ {
+			blocks[d.place(x, y)] = ""
+		}
+	}
+	return blocks
+}
+
+type imageResource struct {
+	provider provider
+}
+
+func (r imageResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data imageResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to read image, got error: %s", err))
+		return
+	}
+	if err := data.size(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to read image, got error: %s", err))
+		return
+	}
+	blocks, err := data.blocks()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to render image, got error: %s", err))
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, blocks); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place image, got error: %s", err))
+		return
+	}
+
+	data.SourceHash = types.String{Value: hash}
+	data.Id = types.String{Value: fmt.Sprintf("image-%d-%d-%d", data.Position.X, data.Position.Y, data.Position.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read checks the drawing against the world files when world_path is set.
+// If any block was changed in game, source_hash is cleared so the next apply
+// draws the whole image again.
+func (r imageResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data imageResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read image, got error: %s", err))
+		return
+	}
+	if hash, err := fileHash(data.Path); world != nil && err == nil && hash == data.SourceHash.Value {
+		if blocks, err := data.blocks(); err == nil {
+			drifted, err := driftedBlocks(world, data.Dimension.Value, blocks)
+			if err != nil {
+				resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read image, got error: %s", err))
+				return
+			}
+			if len(drifted) > 0 {
+				data.SourceHash = types.String{Null: true}
+			}
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r imageResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, prior imageResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to read image, got error: %s", err))
+		return
+	}
+	if err := data.size(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to read image, got error: %s", err))
+		return
+	}
+	blocks, err := data.blocks()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to render image, got error: %s", err))
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, diffBlocks(prior.placed(), blocks)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update image, got error: %s", err))
+		return
+	}
+
+	data.SourceHash = types.String{Value: hash}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r imageResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data imageResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, diffBlocks(data.placed(), nil)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete image, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan validates the configuration, resolves width and height from
+// the image and records the file hash so edits to the file plan an update.
+func (r imageResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data imageResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.validate(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	pathAttr := tftypes.NewAttributePath().WithAttributeName("path")
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(pathAttr, "Invalid Image", err.Error())
+		return
+	}
+	if err := data.size(); err != nil {
+		resp.Diagnostics.AddAttributeError(pathAttr, "Invalid Image", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("width"), data.Width)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("height"), data.Height)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("source_hash"), types.String{Value: hash})...)
+}
+
+func (r imageResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
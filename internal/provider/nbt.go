@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// snbtValidator checks that an attribute holds a well-formed SNBT compound.
+type snbtValidator struct{}
+
+func (v snbtValidator) Description(ctx context.Context) string {
+	return "value must be an SNBT compound such as {Key:\"value\"}"
+}
+
+func (v snbtValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an SNBT compound such as `{Key:\"value\"}`"
+}
+
+func (v snbtValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	var nbt types.String
+	resp.Diagnostics.Append(tfsdk.ValueAs(ctx, req.AttributeConfig, &nbt)...)
+	if resp.Diagnostics.HasError() || nbt.Null || nbt.Unknown {
+		return
+	}
+
+	if err := minecraft.CheckSNBT(nbt.Value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid NBT", err.Error())
+	}
+}
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = playerSpawnpointResourceType{}
+var _ tfsdk.Resource = playerSpawnpointResource{}
+var _ tfsdk.ResourceWithImportState = playerSpawnpointResource{}
+
+type playerSpawnpointResourceType struct{}
+
+func (t playerSpawnpointResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Set a player's **spawnpoint** (wraps `/spawnpoint`), where they respawn after dying. The player must be online when it is set.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"player": {
+				MarkdownDescription: "Name of the player.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"position":  spawnPosition("Block the player respawns at."),
+			"angle":     spawnAngle(),
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the spawnpoint, `spawnpoint-<player>`.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t playerSpawnpointResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return playerSpawnpointResource{provider: provider}, diags
+}
+
+type playerSpawnpointResourceData struct {
+	Id        types.String      `tfsdk:"id"`
+	Dimension types.String      `tfsdk:"dimension"`
+	Player    string            `tfsdk:"player"`
+	Position  spawnPositionData `tfsdk:"position"`
+	Angle     *float64          `tfsdk:"angle"`
+}
+
+type playerSpawnpointResource struct {
+	provider provider
+}
+
+func (r playerSpawnpointResource) set(ctx context.Context, data playerSpawnpointResourceData, diags *diag.Diagnostics) {
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+	if err := client.SetSpawnpoint(ctx, data.Player, data.Position.pos(), angleValue(data.Angle)); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to set spawnpoint of %q, got error: %s", data.Player, err))
+	}
+}
+
+func (r playerSpawnpointResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data playerSpawnpointResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.String{Value: "spawnpoint-" + data.Player}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read records changes from the player's SpawnX/Y/Z, SpawnAngle and
+// SpawnDimension. A player who is offline cannot be read, so the state is
+// kept as is; one who has lost their spawnpoint, e.g. because the bed or
+// respawn anchor there was broken, is planned to get it again.
+func (r playerSpawnpointResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data playerSpawnpointResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	spawn, ok, err := client.GetSpawnpoint(ctx, r.provider.ServerVersion(), data.Player)
+	if errors.Is(err, minecraft.ErrPlayerNotFound) {
+		diags = resp.State.Set(ctx, &data)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read spawnpoint of %q, got error: %s", data.Player, err))
+		return
+	}
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Position = spawnPositionOf(spawn.Pos)
+	data.Angle = readSpawnAngle(data.Angle, spawn.Angle)
+	dimension := minecraft.NormalizeDimension(data.Dimension.Value)
+	if dimension == "" {
+		dimension = "minecraft:overworld"
+	}
+	if dimension != spawn.Dimension {
+		data.Dimension = types.String{Value: spawn.Dimension}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r playerSpawnpointResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data playerSpawnpointResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete leaves the spawnpoint in place: `spawnpoint` cannot clear one. It
+// goes away by itself when its bed or respawn anchor is broken.
+func (r playerSpawnpointResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data playerSpawnpointResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ImportState adopts the spawnpoint of an online player. The import ID is
+// the player's name, or `spawnpoint-<player>`.
+func (r playerSpawnpointResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	player := strings.TrimPrefix(strings.TrimSpace(req.ID), "spawnpoint-")
+	if player == "" {
+		resp.Diagnostics.AddError("Import Error", "Expected the player's name as import ID.")
+		return
+	}
+
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	spawn, ok, err := client.GetSpawnpoint(ctx, r.provider.ServerVersion(), player)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to read spawnpoint of %q, got error: %s", player, err))
+		return
+	}
+	if !ok {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Player %q has no spawnpoint.", player))
+		return
+	}
+
+	data := playerSpawnpointResourceData{
+		Id:        types.String{Value: "spawnpoint-" + player},
+		Dimension: types.String{Value: spawn.Dimension},
+		Player:    player,
+		Position:  spawnPositionOf(spawn.Pos),
+		Angle:     readSpawnAngle(nil, spawn.Angle),
+	}
+	if spawn.Dimension == "minecraft:overworld" {
+		data.Dimension = types.String{Null: true}
+	}
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/anvil"
 	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
 )
 
@@ -17,13 +19,30 @@ type provider struct {
 	address  string
 	password string
 
+	// serverVersion is the Minecraft release of the server, used to pick
+	// version-specific block data and command syntax.
+	serverVersion minecraft.Version
+
+	// worldPath is the server's world directory, read directly when set.
+	worldPath string
+	worldSave *worldSave
+
 	configured bool
 	version    string
 }
 
 type providerData struct {
-	Address  types.String `tfsdk:"address"`
-	Password types.String `tfsdk:"password"`
+	Address       types.String `tfsdk:"address"`
+	Password      types.String `tfsdk:"password"`
+	ServerVersion types.String `tfsdk:"server_version"`
+	WorldPath     types.String `tfsdk:"world_path"`
+}
+
+// worldSave asks the server to save the world once per provider run, so
+// world file reads see the blocks placed before it.
+type worldSave struct {
+	once sync.Once
+	err  error
 }
 
 func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderRequest, resp *tfsdk.ConfigureProviderResponse) {
@@ -65,8 +84,43 @@ func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderReq
 		return
 	}
 
+	var serverVersion string
+	if data.ServerVersion.Null {
+		serverVersion = os.Getenv("MINECRAFT_VERSION")
+	} else {
+		serverVersion = data.ServerVersion.Value
+	}
+
+	version, err := minecraft.ParseVersion(serverVersion)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create client",
+			fmt.Sprintf("Invalid server version: %s", err),
+		)
+		return
+	}
+
+	var worldPath string
+	if data.WorldPath.Null {
+		worldPath = os.Getenv("MINECRAFT_WORLD_PATH")
+	} else {
+		worldPath = data.WorldPath.Value
+	}
+
+	if worldPath != "" {
+		if _, err := anvil.Open(worldPath); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to create client",
+				fmt.Sprintf("Invalid world path: %s", err),
+			)
+			return
+		}
+	}
+
 	p.address = address
 	p.password = password
+	p.serverVersion = version
+	p.worldPath = worldPath
 	p.configured = true
 }
 
@@ -79,6 +133,53 @@ func (p *provider) GetClient(ctx context.Context) (*minecraft.Client, error) {
 	return client, nil
 }
 
+// GetClientInDimension returns a client whose block and entity commands run
+// in the given dimension. A null dimension keeps the overworld.
+func (p *provider) GetClientInDimension(ctx context.Context, dimension types.String) (*minecraft.Client, error) {
+	client, err := p.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scoped := client.InDimension(minecraft.NormalizeDimension(dimension.Value))
+	return &scoped, nil
+}
+
+// ServerVersion returns the configured server version, or the latest
+// supported release when none was configured.
+func (p *provider) ServerVersion() minecraft.Version {
+	if p.serverVersion == (minecraft.Version{}) {
+		return minecraft.MustParseVersion(minecraft.LatestVersion)
+	}
+	return p.serverVersion
+}
+
+// BlockStates returns the block state registry for the server version.
+func (p *provider) BlockStates() (minecraft.BlockStates, error) {
+	return minecraft.BlockStatesFor(p.ServerVersion())
+}
+
+// World returns the world files, or nil when world_path is not set. The
+// first call in a run has the server save so the files are up to date.
+func (p *provider) World(ctx context.Context) (*anvil.World, error) {
+	if p.worldPath == "" {
+		return nil, nil
+	}
+
+	p.worldSave.once.Do(func() {
+		client, err := p.GetClient(ctx)
+		if err != nil {
+			p.worldSave.err = err
+			return
+		}
+		p.worldSave.err = client.SaveAll(ctx)
+	})
+	if p.worldSave.err != nil {
+		return nil, fmt.Errorf("unable to save the world: %w", p.worldSave.err)
+	}
+	return anvil.Open(p.worldPath)
+}
+
 func (p *provider) GetResources(ctx context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
 	return map[string]tfsdk.ResourceType{
 		"minecraft_block":       blockResourceType{},
@@ -86,9 +187,29 @@ func (p *provider) GetResources(ctx context.Context) (map[string]tfsdk.ResourceT
 		"minecraft_bed":         bedResourceType{},
 		"minecraft_stairs":      stairsResourceType{},
 		"minecraft_chest":       chestResourceType{},
+		"minecraft_sign":        signResourceType{},
+		"minecraft_door":        doorResourceType{},
+		"minecraft_trapdoor":    trapdoorResourceType{},
+		"minecraft_fence_gate":  fenceGateResourceType{},
+		"minecraft_slab":        slabResourceType{},
 		"minecraft_team":        teamResourceType{},
 		"minecraft_team_member": teamMemberResourceType{},
 		"minecraft_fill":        fillResourceType{},
+		"minecraft_blocks":      blocksResourceType{},
+		"minecraft_shape":       shapeResourceType{},
+		"minecraft_image":       imageResourceType{},
+		"minecraft_terrain":     terrainResourceType{},
+		"minecraft_voxel_model": voxelModelResourceType{},
+		"minecraft_schematic":   schematicResourceType{},
+		"minecraft_clone":       cloneResourceType{},
+		"minecraft_structure":   structureResourceType{},
+		"minecraft_worldgen_placement": worldgenPlacementResourceType{},
+		"minecraft_biome":       biomeResourceType{},
+		"minecraft_worldborder": worldborderResourceType{},
+		"minecraft_weather":     weatherResourceType{},
+		"minecraft_difficulty":  difficultyResourceType{},
+		"minecraft_world_spawn": worldSpawnResourceType{},
+		"minecraft_player_spawnpoint": playerSpawnpointResourceType{},
 		"minecraft_gamerule":    gameruleResourceType{},
 		"minecraft_op": 		 opResourceType{},
 		"minecraft_gamemode": 	 gamemodeResourceType{},
@@ -115,6 +236,16 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 				Required:            true,
 				Type:                types.StringType,
 			},
+			"server_version": {
+				MarkdownDescription: "The Minecraft Java Edition version of the server, e.g. `1.20.4`. Used to validate block states and pick version-specific command syntax. Defaults to `MINECRAFT_VERSION` or the latest supported release.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"world_path": {
+				MarkdownDescription: "Path to the server's world directory, the one holding `level.dat`, when Terraform runs on the same host as the server. Blocks are then read from the region files instead of over RCON, which lets `minecraft_blocks` detect changes made in game. Defaults to `MINECRAFT_WORLD_PATH`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
 		},
 	}, nil
 }
@@ -122,7 +253,8 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 func New(version string) func() tfsdk.Provider {
 	return func() tfsdk.Provider {
 		return &provider{
-			version: version,
+			version:   version,
+			worldSave: &worldSave{},
 		}
 	}
 }
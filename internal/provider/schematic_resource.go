@@ -0,0 +1,496 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = schematicResourceType{}
+var _ tfsdk.Resource = schematicResource{}
+var _ tfsdk.ResourceWithImportState = schematicResource{}
+var _ tfsdk.ResourceWithModifyPlan = schematicResource{}
+
+type schematicResourceType struct{}
+
+func (t schematicResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "A build from a Sponge schematic (`.schem`) or a vanilla structure file (`.nbt`), with its block entities and optionally its entities.",
+		Attributes: map[string]tfsdk.Attribute{
+			"path": {
+				MarkdownDescription: "Path to a local Sponge schematic (versions 1 to 3) or structure file. Gzipped files are detected automatically.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"position": {
+				MarkdownDescription: "Where the north-west bottom corner of the build goes.",
+				Required:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"x": {
+						MarkdownDescription: "X coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"y": {
+						MarkdownDescription: "Y coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"z": {
+						MarkdownDescription: "Z coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+				}),
+			},
+			"rotation": {
+				MarkdownDescription: "Degrees to turn the build clockwise seen from above: `0`, `90`, `180` or `270`. Block states such as `facing` are turned with it. Defaults to `0`.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"mirror": {
+				MarkdownDescription: "Flip the build before rotating it: `none`, `x` (east-west) or `z` (north-south). Defaults to `none`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"include_air": {
+				MarkdownDescription: "Place the air blocks saved in the file, clearing whatever is there. Defaults to true.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"include_entities": {
+				MarkdownDescription: "Summon the entities saved in the file, such as armor stands and item frames. They are tagged with the resource ID and removed on destroy. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"block_count": {
+				MarkdownDescription: "Number of blocks placed.",
+				Computed:            true,
+				Type:                types.Int64Type,
+			},
+			"size": {
+				MarkdownDescription: "Size of the placed build in blocks along X, Y and Z, after rotation.",
+				Computed:            true,
+				Type:                types.ObjectType{AttrTypes: sizeAttrTypes},
+			},
+			"source_hash": {
+				MarkdownDescription: "SHA-256 of the file, so editing the file triggers an update. When `world_path` is set, refresh clears it if any placed block was changed in game.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the schematic resource.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t schematicResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return schematicResource{provider: provider}, diags
+}
+
+type schematicResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Path      string       `tfsdk:"path"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+	Rotation        *int         `tfsdk:"rotation"`
+	Mirror          types.String `tfsdk:"mirror"`
+	IncludeAir      *bool        `tfsdk:"include_air"`
+	IncludeEntities *bool        `tfsdk:"include_entities"`
+	BlockCount      types.Int64  `tfsdk:"block_count"`
+	Size            types.Object `tfsdk:"size"`
+	SourceHash      types.String `tfsdk:"source_hash"`
+}
+
+// schematicBuild is a schematic placed in the world.
+type schematicBuild struct {
+	blocks        map[minecraft.Pos]string
+	blockEntities map[minecraft.Pos]string // SNBT
+	entities      []minecraft.SchematicEntity
+	size          minecraft.Pos
+}
+
+func (d schematicResourceData) origin() minecraft.Pos {
+	return minecraft.Pos{X: d.Position.X, Y: d.Position.Y, Z: d.Position.Z}
+}
+
+func (d schematicResourceData) transform() minecraft.Transform {
+	t := minecraft.Transform{Mirror: d.Mirror.Value}
+	if d.Rotation != nil {
+		t.Rotation = *d.Rotation
+	}
+	return t
+}
+
+func (d schematicResourceData) entities() bool {
+	return d.IncludeEntities != nil && *d.IncludeEntities
+}
+
+// build reads the file and places it for a server running version.
+func (d schematicResourceData) build(version minecraft.Version) (schematicBuild, error) {
+	t := d.transform()
+	if err := t.Validate(); err != nil {
+		return schematicBuild{}, err
+	}
+
+	s, err := minecraft.ReadSchematic(d.Path)
+	if err != nil {
+		return schematicBuild{}, err
+	}
+	if err := s.Translate(version); err != nil {
+		return schematicBuild{}, err
+	}
+
+	blocks, blockEntities, entities := s.Place(d.origin(), t)
+	b := schematicBuild{
+		blocks:        blocks,
+		blockEntities: map[minecraft.Pos]string{},
+		size:          t.Size(s.Size),
+	}
+	if d.IncludeAir != nil && !*d.IncludeAir {
+		for p, block := range blocks {
+			if block == "minecraft:air" || block == "minecraft:cave_air" {
+				delete(blocks, p)
+			}
+		}
+	}
+	for p, data := range blockEntities {
+		if _, ok := blocks[p]; ok && len(data) > 0 {
+			b.blockEntities[p] = nbt.Stringify(data)
+		}
+	}
+	if d.entities() {
+		b.entities = entities
+	}
+	return b, nil
+}
+
+// placed returns the build this state put in the world, or false if the
+// file has changed since.
+func (d schematicResourceData) placed(version minecraft.Version) (schematicBuild, bool) {
+	hash, err := fileHash(d.Path)
+	if err != nil || hash != d.SourceHash.Value {
+		return schematicBuild{}, false
+	}
+	b, err := d.build(version)
+	if err != nil {
+		return schematicBuild{}, false
+	}
+	return b, true
+}
+
+// mergeBlockEntities writes block entity data, in position order.
+func mergeBlockEntities(ctx context.Context, client *minecraft.Client, blockEntities map[minecraft.Pos]string) error {
+	for _, p := range minecraft.SortedPositions(blockEntities) {
+		if err := client.MergeBlockData(ctx, blockEntities[p], p.X, p.Y, p.Z); err != nil {
+			return fmt.Errorf("block entity at %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// summonEntities summons the build's entities, tagged so they can be
+// removed later.
+func summonEntities(ctx context.Context, client *minecraft.Client, entities []minecraft.SchematicEntity, tag string) error {
+	for _, e := range entities {
+		data := nbt.Compound{}
+		for k, v := range e.NBT {
+			data[k] = v
+		}
+		tags, _ := data["Tags"].(nbt.List)
+		data["Tags"] = nbt.List{Type: nbt.TagString, Items: append(append([]interface{}{}, tags.Items...), tag)}
+		if err := client.SummonEntity(ctx, e.ID, e.Pos[0], e.Pos[1], e.Pos[2], nbt.Stringify(data)); err != nil {
+			return fmt.Errorf("%s: %w", e.ID, err)
+		}
+	}
+	return nil
+}
+
+// entityTag is the tag carried by entities summoned for a schematic.
+func entityTag(id string) string {
+	return "terraform." + id
+}
+
+type schematicResource struct {
+	provider provider
+}
+
+func (r schematicResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data schematicResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to read schematic, got error: %s", err))
+		return
+	}
+	b, err := data.build(r.provider.ServerVersion())
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	data.Id = types.String{Value: fmt.Sprintf("schematic-%d-%d-%d", data.Position.X, data.Position.Y, data.Position.Z)}
+
+	if _, err := client.SetBlocks(ctx, b.blocks); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place schematic, got error: %s", err))
+		return
+	}
+	if err := mergeBlockEntities(ctx, client, b.blockEntities); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place schematic, got error: %s", err))
+		return
+	}
+	if err := summonEntities(ctx, client, b.entities, entityTag(data.Id.Value)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to summon schematic entities, got error: %s", err))
+		return
+	}
+
+	data.BlockCount = types.Int64{Value: int64(len(b.blocks))}
+	data.Size = sizeObject(b.size)
+	data.SourceHash = types.String{Value: hash}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read compares the placed blocks with the world files when world_path is
+// set. Block entity data and entities are not compared. On any difference
+// source_hash is cleared, and the next apply places the schematic again.
+func (r schematicResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data schematicResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read schematic, got error: %s", err))
+		return
+	}
+	if world != nil {
+		if b, ok := data.placed(r.provider.ServerVersion()); ok {
+			drifted, err := driftedBlocks(world, data.Dimension.Value, b.blocks)
+			if err != nil {
+				resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read schematic, got error: %s", err))
+				return
+			}
+			if len(drifted) > 0 {
+				data.SourceHash = types.String{Null: true}
+			}
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only rewrites the blocks that differ between the old and new build,
+// plus block entities whose block was rewritten or whose data changed.
+// Entities are removed and summoned again.
+func (r schematicResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, prior schematicResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	version := r.provider.ServerVersion()
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to read schematic, got error: %s", err))
+		return
+	}
+	b, err := data.build(version)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	changes, merge := b.blocks, b.blockEntities
+	if old, ok := prior.placed(version); ok {
+		changes = diffBlocks(old.blocks, b.blocks)
+		merge = map[minecraft.Pos]string{}
+		for p, snbt := range b.blockEntities {
+			if _, rewritten := changes[p]; rewritten || old.blockEntities[p] != snbt {
+				merge[p] = snbt
+			}
+		}
+	} else if err := clearFootprint(ctx, client, prior.origin(), prior.Size); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clear old schematic, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, changes); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update schematic, got error: %s", err))
+		return
+	}
+	if err := mergeBlockEntities(ctx, client, merge); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update schematic, got error: %s", err))
+		return
+	}
+	if prior.entities() {
+		if err := client.KillEntitiesByTag(ctx, entityTag(prior.Id.Value)); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove schematic entities, got error: %s", err))
+			return
+		}
+	}
+	if err := summonEntities(ctx, client, b.entities, entityTag(data.Id.Value)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to summon schematic entities, got error: %s", err))
+		return
+	}
+
+	data.BlockCount = types.Int64{Value: int64(len(b.blocks))}
+	data.Size = sizeObject(b.size)
+	data.SourceHash = types.String{Value: hash}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r schematicResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data schematicResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if data.entities() {
+		if err := client.KillEntitiesByTag(ctx, entityTag(data.Id.Value)); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove schematic entities, got error: %s", err))
+			return
+		}
+	}
+
+	if old, ok := data.placed(r.provider.ServerVersion()); ok {
+		_, err = client.SetBlocks(ctx, diffBlocks(old.blocks, nil))
+	} else {
+		err = clearFootprint(ctx, client, data.origin(), data.Size)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete schematic, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan reads the file, warns about blocks the server version may not
+// have, and works out block_count, size and the file hash so they are known
+// at plan time.
+func (r schematicResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data schematicResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.transform().Validate(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	pathAttr := tftypes.NewAttributePath().WithAttributeName("path")
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(pathAttr, "Invalid Schematic", err.Error())
+		return
+	}
+	b, err := data.build(r.provider.ServerVersion())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(pathAttr, "Invalid Schematic", err.Error())
+		return
+	}
+
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+	if problems := checkBlocks(registry, b.blocks); len(problems) > 0 {
+		resp.Diagnostics.AddAttributeWarning(pathAttr, "Unrecognised Blocks",
+			fmt.Sprintf("Some blocks in the schematic may not exist on Minecraft %s and will fail to place:\n%s", r.provider.ServerVersion(), strings.Join(problems, "\n")))
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("block_count"), types.Int64{Value: int64(len(b.blocks))})...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("size"), sizeObject(b.size))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("source_hash"), types.String{Value: hash})...)
+}
+
+// checkBlocks validates every distinct block against the registry and
+// returns up to ten problems. Modded blocks are skipped.
+func checkBlocks(registry minecraft.BlockStates, blocks map[minecraft.Pos]string) []string {
+	seen := map[string]bool{}
+	var problems []string
+	for _, block := range blocks {
+		if seen[block] {
+			continue
+		}
+		seen[block] = true
+
+		id, state := minecraft.ParseBlock(block)
+		if !strings.HasPrefix(id, "minecraft:") {
+			continue
+		}
+		if err := registry.Validate(id, state); err != nil && !errors.Is(err, minecraft.ErrUnknownBlock) {
+			problems = append(problems, "- "+err.Error())
+		}
+	}
+	sort.Strings(problems)
+	if len(problems) > 10 {
+		problems = append(problems[:10], fmt.Sprintf("- … and %d more", len(problems)-10))
+	}
+	return problems
+}
+
+func (r schematicResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
@@ -0,0 +1,385 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = shapeResourceType{}
+var _ tfsdk.Resource = shapeResource{}
+var _ tfsdk.ResourceWithImportState = shapeResource{}
+var _ tfsdk.ResourceWithModifyPlan = shapeResource{}
+
+type shapeResourceType struct{}
+
+func shapeVectorAttribute(description string) tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+			"x": {
+				MarkdownDescription: "X component",
+				Type:                types.NumberType,
+				Required:            true,
+			},
+			"y": {
+				MarkdownDescription: "Y component",
+				Type:                types.NumberType,
+				Required:            true,
+			},
+			"z": {
+				MarkdownDescription: "Z component",
+				Type:                types.NumberType,
+				Required:            true,
+			},
+		}),
+	}
+}
+
+func (t shapeResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "A geometric shape (sphere, ellipsoid, cylinder, cone, pyramid, torus or line) of one material, rasterised into blocks and placed with as few `/fill` commands as possible.",
+		Attributes: map[string]tfsdk.Attribute{
+			"type": {
+				MarkdownDescription: "The shape: `sphere`, `ellipsoid`, `cylinder`, `cone`, `pyramid`, `torus` or `line`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"material": {
+				MarkdownDescription: "The block to build with, e.g. `minecraft:glass`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"position": {
+				MarkdownDescription: "The centre of a sphere, ellipsoid or torus; the centre of the base of a cylinder, cone or pyramid; the start of a line.",
+				Required:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"x": {
+						MarkdownDescription: "X coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"y": {
+						MarkdownDescription: "Y coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"z": {
+						MarkdownDescription: "Z coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+				}),
+			},
+			"radius": {
+				MarkdownDescription: "Radius of a sphere, cylinder or cone, half the base width of a pyramid, or the distance from the centre of a torus to the middle of its tube.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"radii": shapeVectorAttribute("Radii of an ellipsoid along X, Y and Z."),
+			"height": {
+				MarkdownDescription: "Height of a cylinder, cone or pyramid in blocks, measured along `axis`.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"minor_radius": {
+				MarkdownDescription: "Radius of the tube of a torus.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"end": shapeVectorAttribute("End position of a line."),
+			"axis": {
+				MarkdownDescription: "Axis a cylinder, cone or pyramid grows along and a torus lies around: `x`, `y` or `z`. Defaults to `y`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"hollow": {
+				MarkdownDescription: "Build only the outer shell. Ignored for lines. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"thickness": {
+				MarkdownDescription: "Shell thickness of a hollow shape, or the width of a line. Defaults to `1`.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"voxel_count": {
+				MarkdownDescription: "Number of blocks in the shape. When `world_path` is set, refresh counts only the blocks still in place, so blocks changed in game are put back on the next apply.",
+				Computed:            true,
+				Type:                types.Int64Type,
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the shape resource.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t shapeResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return shapeResource{provider: provider}, diags
+}
+
+type shapeVector struct {
+	X int `tfsdk:"x"`
+	Y int `tfsdk:"y"`
+	Z int `tfsdk:"z"`
+}
+
+type shapeResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Type      string       `tfsdk:"type"`
+	Material  string       `tfsdk:"material"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+	Radius      *int         `tfsdk:"radius"`
+	Radii       *shapeVector `tfsdk:"radii"`
+	Height      *int         `tfsdk:"height"`
+	MinorRadius *int         `tfsdk:"minor_radius"`
+	End         *shapeVector `tfsdk:"end"`
+	Axis        types.String `tfsdk:"axis"`
+	Hollow      *bool        `tfsdk:"hollow"`
+	Thickness   *int         `tfsdk:"thickness"`
+	VoxelCount  types.Int64  `tfsdk:"voxel_count"`
+}
+
+func (d shapeResourceData) shape() (minecraft.Shape, error) {
+	s := minecraft.Shape{
+		Type:   d.Type,
+		Center: minecraft.Pos{X: d.Position.X, Y: d.Position.Y, Z: d.Position.Z},
+		Axis:   d.Axis.Value,
+		Hollow: d.Hollow != nil && *d.Hollow,
+	}
+	if d.Thickness != nil {
+		s.Thickness = *d.Thickness
+	}
+	if d.Height != nil {
+		s.Height = *d.Height
+	}
+	if d.MinorRadius != nil {
+		s.MinorRadius = *d.MinorRadius
+	}
+
+	switch d.Type {
+	case "sphere", "cylinder", "cone", "pyramid", "torus":
+		if d.Radius == nil {
+			return s, fmt.Errorf("a %s needs radius", d.Type)
+		}
+		s.Radius = *d.Radius
+	case "ellipsoid":
+		if d.Radii == nil {
+			return s, fmt.Errorf("an ellipsoid needs radii")
+		}
+		s.Radii = minecraft.Pos{X: d.Radii.X, Y: d.Radii.Y, Z: d.Radii.Z}
+	case "line":
+		if d.End == nil {
+			return s, fmt.Errorf("a line needs end")
+		}
+		s.End = minecraft.Pos{X: d.End.X, Y: d.End.Y, Z: d.End.Z}
+	}
+	switch d.Type {
+	case "cylinder", "cone", "pyramid":
+		if d.Height == nil {
+			return s, fmt.Errorf("a %s needs height", d.Type)
+		}
+	case "torus":
+		if d.MinorRadius == nil {
+			return s, fmt.Errorf("a torus needs minor_radius")
+		}
+	}
+	return s, s.Validate()
+}
+
+// blocks rasterises the shape into a block map of its material.
+func (d shapeResourceData) blocks() (map[minecraft.Pos]string, error) {
+	s, err := d.shape()
+	if err != nil {
+		return nil, err
+	}
+	voxels, err := s.Voxels()
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make(map[minecraft.Pos]string, len(voxels))
+	for _, p := range voxels {
+		blocks[p] = d.Material
+	}
+	return blocks, nil
+}
+
+type shapeResource struct {
+	provider provider
+}
+
+func (r shapeResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data shapeResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blocks, err := data.blocks()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, blocks); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place %s, got error: %s", data.Type, err))
+		return
+	}
+
+	data.VoxelCount = types.Int64{Value: int64(len(blocks))}
+	data.Id = types.String{Value: fmt.Sprintf("shape-%s-%d-%d-%d", data.Type, data.Position.X, data.Position.Y, data.Position.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read checks the shape's blocks against the world files, when world_path
+// is set, and lowers voxel_count by the number that were changed in game.
+func (r shapeResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data shapeResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read %s, got error: %s", data.Type, err))
+		return
+	}
+	if blocks, err := data.blocks(); world != nil && err == nil {
+		drifted, err := driftedBlocks(world, data.Dimension.Value, blocks)
+		if err != nil {
+			resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read %s, got error: %s", data.Type, err))
+			return
+		}
+		data.VoxelCount = types.Int64{Value: int64(len(blocks) - len(drifted))}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only rewrites the blocks that differ between the old and new shape.
+func (r shapeResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, prior shapeResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blocks, err := data.blocks()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+	old, err := prior.blocks()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	changes := diffBlocks(old, blocks)
+	if prior.VoxelCount.Value != int64(len(old)) {
+		// Refresh found blocks changed in game, so write every block, not
+		// just the ones the shape moved.
+		for p, block := range blocks {
+			changes[p] = block
+		}
+	}
+	if _, err := client.SetBlocks(ctx, changes); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update %s, got error: %s", data.Type, err))
+		return
+	}
+
+	data.VoxelCount = types.Int64{Value: int64(len(blocks))}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r shapeResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data shapeResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blocks, err := data.blocks()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, diffBlocks(blocks, nil)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete %s, got error: %s", data.Type, err))
+		return
+	}
+}
+
+// ModifyPlan validates the shape and works out voxel_count so it is known at
+// plan time.
+func (r shapeResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data shapeResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blocks, err := data.blocks()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("voxel_count"), types.Int64{Value: int64(len(blocks))})...)
+}
+
+func (r shapeResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
@@ -0,0 +1,439 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = signResourceType{}
+var _ tfsdk.Resource = signResource{}
+var _ tfsdk.ResourceWithImportState = signResource{}
+var _ tfsdk.ResourceWithModifyPlan = signResource{}
+
+type signResourceType struct{}
+
+func signTextAttributes(side string) tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: fmt.Sprintf("Text on the %s of the sign.", side),
+		Optional:            true,
+		Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+			"lines": {
+				MarkdownDescription: "Up to four lines of plain text.",
+				Required:            true,
+				Type:                types.ListType{ElemType: types.StringType},
+			},
+			"color": {
+				MarkdownDescription: "Text dye colour, e.g. `black`, `white`, `red`. Defaults to `black`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"glowing": {
+				MarkdownDescription: "Whether the text glows (glow ink sac). Requires Minecraft 1.17+. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+		}),
+	}
+}
+
+func (t signResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "A Minecraft sign with text on the front and (1.20+) back.",
+		Attributes: map[string]tfsdk.Attribute{
+			"wood": {
+				MarkdownDescription: "The wood type, e.g. `oak`, `spruce`, `birch`, `jungle`, `acacia`, `dark_oak`, `mangrove`, `cherry`, `bamboo`, `crimson`, `warped`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"placement": {
+				MarkdownDescription: "How the sign is mounted: `standing`, `wall`, `hanging` or `wall_hanging` (hanging signs need 1.20+). Defaults to `standing`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"facing": {
+				MarkdownDescription: "Direction a `wall` or `wall_hanging` sign faces: one of `north`, `south`, `east`, `west`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"rotation": {
+				MarkdownDescription: "Rotation of a `standing` or `hanging` sign in sixteenths of a turn, `0` (south) to `15`. Defaults to `0`.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"front": signTextAttributes("front"),
+			"back":  signTextAttributes("back (requires Minecraft 1.20+)"),
+			"waxed": {
+				MarkdownDescription: "Whether the sign is waxed so players can't edit it. Requires Minecraft 1.20+. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"position": {
+				MarkdownDescription: "The position of the sign.",
+				Required:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"x": {
+						MarkdownDescription: "X coordinate of the sign",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+					"y": {
+						MarkdownDescription: "Y coordinate of the sign",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+					"z": {
+						MarkdownDescription: "Z coordinate of the sign",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+				}),
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the sign",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t signResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return signResource{provider: provider}, diags
+}
+
+type signTextData struct {
+	Lines   []string     `tfsdk:"lines"`
+	Color   types.String `tfsdk:"color"`
+	Glowing *bool        `tfsdk:"glowing"`
+}
+
+type signResourceData struct {
+	Id        types.String  `tfsdk:"id"`
+	Dimension types.String  `tfsdk:"dimension"`
+	Wood      string        `tfsdk:"wood"`
+	Placement types.String  `tfsdk:"placement"` // standing|wall|hanging|wall_hanging
+	Facing    types.String  `tfsdk:"facing"`    // north|south|east|west
+	Rotation  *int          `tfsdk:"rotation"`  // 0-15
+	Front     *signTextData `tfsdk:"front"`
+	Back      *signTextData `tfsdk:"back"`
+	Waxed     *bool         `tfsdk:"waxed"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+}
+
+type signResource struct {
+	provider provider
+}
+
+// block returns the sign block ID and its block state.
+func (d signResourceData) block() (string, map[string]string) {
+	wood := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(d.Wood)), "minecraft:")
+
+	rotation := 0
+	if d.Rotation != nil {
+		rotation = *d.Rotation
+	}
+
+	switch d.placement() {
+	case "wall":
+		return "minecraft:" + wood + "_wall_sign", map[string]string{"facing": d.Facing.Value}
+	case "hanging":
+		return "minecraft:" + wood + "_hanging_sign", map[string]string{"rotation": strconv.Itoa(rotation), "attached": "false"}
+	case "wall_hanging":
+		return "minecraft:" + wood + "_wall_hanging_sign", map[string]string{"facing": d.Facing.Value}
+	default:
+		return "minecraft:" + wood + "_sign", map[string]string{"rotation": strconv.Itoa(rotation)}
+	}
+}
+
+func (d signResourceData) placement() string {
+	if d.Placement.Null || d.Placement.Value == "" {
+		return "standing"
+	}
+	return d.Placement.Value
+}
+
+func (d signResourceData) sign() minecraft.Sign {
+	var sign minecraft.Sign
+	sign.Front = d.Front.text()
+	sign.Back = d.Back.text()
+	if d.Waxed != nil {
+		sign.Waxed = *d.Waxed
+	}
+	return sign
+}
+
+func (t *signTextData) text() minecraft.SignText {
+	if t == nil {
+		return minecraft.SignText{}
+	}
+	text := minecraft.SignText{Lines: t.Lines, Color: t.Color.Value}
+	if t.Glowing != nil {
+		text.Glowing = *t.Glowing
+	}
+	return text
+}
+
+// validate checks the sign against the server version.
+func (d signResourceData) validate(version minecraft.Version, registry minecraft.BlockStates) error {
+	switch d.placement() {
+	case "standing", "hanging":
+		if !d.Facing.Null {
+			return fmt.Errorf("facing only applies to wall and wall_hanging signs; use rotation")
+		}
+		if d.Rotation != nil && (*d.Rotation < 0 || *d.Rotation > 15) {
+			return fmt.Errorf("rotation must be between 0 and 15 (got %d)", *d.Rotation)
+		}
+	case "wall", "wall_hanging":
+		if d.Rotation != nil {
+			return fmt.Errorf("rotation only applies to standing and hanging signs; use facing")
+		}
+		if _, _, ok := bedOffset(d.Facing.Value); !ok {
+			return fmt.Errorf("facing must be one of north|south|east|west for %s signs", d.placement())
+		}
+	default:
+		return fmt.Errorf("placement must be one of standing|wall|hanging|wall_hanging (got %q)", d.placement())
+	}
+
+	for name, side := range map[string]*signTextData{"front": d.Front, "back": d.Back} {
+		if side == nil {
+			continue
+		}
+		if len(side.Lines) > 4 {
+			return fmt.Errorf("%s can hold at most 4 lines (got %d)", name, len(side.Lines))
+		}
+		if !side.Color.Null && !minecraft.IsDyeColor(side.Color.Value) {
+			return fmt.Errorf("%s color must be a dye colour such as black, white or red (got %q)", name, side.Color.Value)
+		}
+		if side.Glowing != nil && *side.Glowing && !minecraft.SignSupportsGlowing(version) {
+			return fmt.Errorf("glowing sign text requires Minecraft 1.17 or later (server is %s)", version)
+		}
+	}
+
+	if !minecraft.SignSupportsSides(version) {
+		if d.Back != nil {
+			return fmt.Errorf("back text requires Minecraft 1.20 or later (server is %s)", version)
+		}
+		if d.Waxed != nil && *d.Waxed {
+			return fmt.Errorf("waxed signs require Minecraft 1.20 or later (server is %s)", version)
+		}
+	}
+
+	material, state := d.block()
+	return registry.Validate(material, state)
+}
+
+func (r signResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data signResourceData
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	material, state := data.block()
+	block := minecraft.BlockWithState(material, state) + minecraft.SignNBT(r.provider.ServerVersion(), data.sign())
+	if err := client.CreateBlock(ctx, block, data.Position.X, data.Position.Y, data.Position.Z); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create sign, got error: %s", err))
+		return
+	}
+
+	data.Id = types.String{Value: fmt.Sprintf("sign-%d-%d-%d", data.Position.X, data.Position.Y, data.Position.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read reads the sign text back from the block entity. A missing sign
+// removes the resource so it is planned for re-creation.
+func (r signResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data signResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	material, state := data.block()
+	exists, err := client.TestBlock(ctx, minecraft.BlockWithState(material, state), data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sign, got error: %s", err))
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	raw, err := client.GetBlockData(ctx, data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read sign text, got error: %s", err))
+		return
+	}
+	current, err := minecraft.ParseSignNBT(raw)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to parse sign text, got error: %s", err))
+		return
+	}
+
+	data.Front = readSignText(data.Front, current.Front)
+	if minecraft.SignSupportsSides(r.provider.ServerVersion()) {
+		data.Back = readSignText(data.Back, current.Back)
+		if data.Waxed != nil || current.Waxed {
+			data.Waxed = &current.Waxed
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// readSignText merges text read from the server into prior state, keeping
+// unset optional attributes null while they hold the game's defaults.
+func readSignText(prior *signTextData, current minecraft.SignText) *signTextData {
+	// Trim the trailing blank lines the game always stores, but keep as many
+	// lines as were configured.
+	lines := current.Lines
+	keep := 0
+	if prior != nil {
+		keep = len(prior.Lines)
+	}
+	for len(lines) > keep && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	blank := len(lines) == 0 && current.Color == "black" && !current.Glowing
+	if prior == nil && blank {
+		return nil
+	}
+
+	text := &signTextData{Lines: lines, Color: types.String{Null: true}}
+	if lines == nil {
+		text.Lines = []string{}
+	}
+	if (prior != nil && !prior.Color.Null) || current.Color != "black" {
+		text.Color = types.String{Value: current.Color}
+	}
+	if (prior != nil && prior.Glowing != nil) || current.Glowing {
+		glowing := current.Glowing
+		text.Glowing = &glowing
+	}
+	return text
+}
+
+func (r signResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data signResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	material, state := data.block()
+	nbt := minecraft.SignNBT(r.provider.ServerVersion(), data.sign())
+	if err := client.CreateBlock(ctx, minecraft.BlockWithState(material, state)+nbt, data.Position.X, data.Position.Y, data.Position.Z); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update sign, got error: %s", err))
+		return
+	}
+
+	// setblock is a no-op when the block state is unchanged, so merge the
+	// text explicitly.
+	if err := client.MergeBlockData(ctx, nbt, data.Position.X, data.Position.Y, data.Position.Z); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update sign text, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r signResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data signResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	err = client.DeleteBlock(ctx, data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete sign, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan validates the sign against the configured server version.
+func (r signResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data signResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+
+	if err := data.validate(r.provider.ServerVersion(), registry); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+	}
+}
+
+func (r signResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
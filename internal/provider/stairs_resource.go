@@ -79,6 +79,7 @@ func (t stairsResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.D
 				Type:                types.BoolType,
 			},
 
+			"dimension": dimensionAttribute(),
 			"id": {
 				Computed:            true,
 				MarkdownDescription: "ID of the block",
@@ -97,9 +98,10 @@ func (t stairsResourceType) NewResource(ctx context.Context, in tfsdk.Provider)
 }
 
 type stairsResourceData struct {
-	Id       types.String `tfsdk:"id"`
-	Material string       `tfsdk:"material"`
-	Position struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Material  string       `tfsdk:"material"`
+	Position  struct {
 		X int `tfsdk:"x"`
 		Y int `tfsdk:"y"`
 		Z int `tfsdk:"z"`
@@ -123,7 +125,7 @@ func (r stairsResource) Create(ctx context.Context, req tfsdk.CreateResourceRequ
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
 		return
@@ -180,7 +182,7 @@ func (r stairsResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequ
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
 		return
@@ -217,7 +219,7 @@ func (r stairsResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequ
 		return
 	}
 
-	client, err := r.provider.GetClient(ctx)
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
 		return
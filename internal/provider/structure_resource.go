@@ -0,0 +1,358 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/export"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/nbt"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = structureResourceType{}
+var _ tfsdk.Resource = structureResource{}
+var _ tfsdk.ResourceWithImportState = structureResource{}
+var _ tfsdk.ResourceWithModifyPlan = structureResource{}
+
+type structureResourceType struct{}
+
+func (t structureResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "A **structure template** from the server or a datapack, placed with `/place template`. Servers older than 1.19 load it through a structure block instead. The blocks it replaces are put back on destroy.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"structure": {
+				MarkdownDescription: "Resource location of the template, e.g. `minecraft:igloo/top` or `mypack:houses/cottage`.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"position": fixedPosition("Where the template's origin corner goes. Rotated and mirrored templates turn around this corner, so they may reach west or north of it."),
+			"rotation": {
+				MarkdownDescription: "Degrees to turn the template clockwise seen from above: `0`, `90`, `180` or `270`. Defaults to `0`.",
+				Optional:            true,
+				Type:                types.NumberType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"mirror": {
+				MarkdownDescription: "Flip the template before rotating it: `none`, `x` (east-west) or `z` (north-south). Defaults to `none`.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"integrity": {
+				MarkdownDescription: "Chance, from `0` to `1`, that each block of the template is placed, for ruined or weathered builds. Defaults to `1`.",
+				Optional:            true,
+				Type:                types.NumberType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"seed": {
+				MarkdownDescription: "Seed picking which blocks are left out when `integrity` is below 1. Defaults to `0`, a random pick.",
+				Optional:            true,
+				Type:                types.NumberType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"size": {
+				MarkdownDescription: "Size of the template along X, Y and Z, before rotation. Read from the server with a structure block when not set.",
+				Optional:            true,
+				Computed:            true,
+				Type:                types.ObjectType{AttrTypes: sizeAttrTypes},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"previous_blocks": {
+				MarkdownDescription: "The blocks other than air the template replaced, keyed by `x,y,z`, with their block entity data. They are put back on destroy.",
+				Computed:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the structure.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t structureResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return structureResource{provider: provider}, diags
+}
+
+type structureResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Structure string       `tfsdk:"structure"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+	Rotation       *int         `tfsdk:"rotation"`
+	Mirror         types.String `tfsdk:"mirror"`
+	Integrity      *float64     `tfsdk:"integrity"`
+	Seed           *int64       `tfsdk:"seed"`
+	Size           types.Object `tfsdk:"size"`
+	PreviousBlocks types.Map    `tfsdk:"previous_blocks"`
+}
+
+func (d structureResourceData) template() minecraft.Template {
+	t := minecraft.Template{
+		Name:      d.Structure,
+		Position:  minecraft.Pos{X: d.Position.X, Y: d.Position.Y, Z: d.Position.Z},
+		Transform: minecraft.Transform{Mirror: d.Mirror.Value},
+		Integrity: 1,
+	}
+	if d.Rotation != nil {
+		t.Transform.Rotation = *d.Rotation
+	}
+	if d.Integrity != nil {
+		t.Integrity = *d.Integrity
+	}
+	if d.Seed != nil {
+		t.Seed = *d.Seed
+	}
+	return t
+}
+
+type structureResource struct {
+	provider provider
+}
+
+func (r structureResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data structureResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+
+	template := data.template()
+	size, ok := objectSize(data.Size)
+	if !ok {
+		size, err = client.TemplateSize(ctx, registry, template)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read template size, got error: %s", err))
+			return
+		}
+		data.Size = sizeObject(size)
+	}
+
+	previous, unknown, err := captureArea(ctx, r.provider, client, registry, data.Dimension, template.Box(size))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to capture the area, got error: %s", err))
+		return
+	}
+	if unknown > 0 {
+		resp.Diagnostics.AddWarning("Unknown Blocks",
+			fmt.Sprintf("%d blocks in the area could not be identified and will be cleared to air on destroy.", unknown))
+	}
+
+	if err := client.PlaceTemplate(ctx, r.provider.ServerVersion(), registry, template); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place structure, got error: %s", err))
+		return
+	}
+
+	data.PreviousBlocks = types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}}
+	for p, block := range previous {
+		// The area is cleared to air on destroy, so only other blocks matter.
+		if id, _ := minecraft.ParseBlock(block); !isAirBlock(id) {
+			data.PreviousBlocks.Elems[p.String()] = types.String{Value: block}
+		}
+	}
+	data.Id = types.String{Value: fmt.Sprintf("structure-%d-%d-%d", data.Position.X, data.Position.Y, data.Position.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// maxRCONCapture is the largest area captureArea reads over RCON. Every
+// block costs at least one command, and anything but air several more, so
+// larger areas need world_path.
+const maxRCONCapture = 5000
+
+// checkCaptureVolume rejects an area too large to capture without world
+// files.
+func checkCaptureVolume(p provider, box minecraft.Box) error {
+	if p.worldPath == "" && box.Volume() > maxRCONCapture {
+		return fmt.Errorf("reading the %d blocks of the area over RCON would take too long; set `world_path` on the provider to read them from the world files, or keep the area to %d blocks or fewer", box.Volume(), maxRCONCapture)
+	}
+	return nil
+}
+
+// captureArea reads every block in box, with its block entity data
+// appended, from the world files when world_path is set and over RCON
+// otherwise. It also returns how many blocks could not be identified.
+func captureArea(ctx context.Context, p provider, client *minecraft.Client, registry minecraft.BlockStates, dimension types.String, box minecraft.Box) (map[minecraft.Pos]string, int, error) {
+	if err := checkCaptureVolume(p, box); err != nil {
+		return nil, 0, err
+	}
+	world, err := p.World(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	var src export.Source = export.NewRCON(*client, registry)
+	if world != nil {
+		// Blocks placed earlier in this run may not be saved yet.
+		if err := client.SaveAll(ctx); err != nil {
+			return nil, 0, err
+		}
+		src = export.NewWorld(world.Dimension(minecraft.NormalizeDimension(dimension.Value)))
+	}
+
+	region, err := export.Read(ctx, src, box, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	blocks := make(map[minecraft.Pos]string, len(region.Blocks))
+	for pos, block := range region.Blocks {
+		if data, ok := region.BlockEntities[pos]; ok {
+			block += nbt.Stringify(data)
+		}
+		blocks[pos] = block
+	}
+	return blocks, len(region.Unknown), nil
+}
+
+// Read is a no-op: the template is placed once, and players are expected to
+// change it in game afterwards.
+func (r structureResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data structureResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update has nothing to do: every argument replaces the structure.
+func (r structureResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data structureResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete clears the template's area and puts back the blocks it replaced.
+// Entities the template summoned are left alone.
+func (r structureResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data structureResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	size, ok := objectSize(data.Size)
+	if !ok {
+		return
+	}
+	var blocks map[string]string
+	resp.Diagnostics.Append(data.PreviousBlocks.ElementsAs(ctx, &blocks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	previous, err := parseBlockMap(blocks)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	area := data.template().Box(size)
+	area.Block = "minecraft:air"
+	for _, box := range minecraft.SplitBox(area) {
+		if err := client.FillBox(ctx, box); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clear structure, got error: %s", err))
+			return
+		}
+	}
+	if _, err := client.SetBlocks(ctx, previous); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to restore the area, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan checks the template settings, and that the area is small
+// enough to record without world files, before anything is sent to the
+// server.
+func (r structureResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data structureResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.template().Validate(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+	size, ok := objectSize(data.Size)
+	if !ok {
+		return
+	}
+	path := tftypes.NewAttributePath().WithAttributeName("size")
+	if size.X < 1 || size.Y < 1 || size.Z < 1 {
+		resp.Diagnostics.AddAttributeError(path, "Validation Error",
+			"Every side of the template must be at least one block.")
+		return
+	}
+	if err := checkCaptureVolume(r.provider, data.template().Box(size)); err != nil {
+		resp.Diagnostics.AddAttributeError(path, "Validation Error", err.Error())
+	}
+}
+
+func (r structureResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
@@ -0,0 +1,373 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = terrainResourceType{}
+var _ tfsdk.Resource = terrainResource{}
+var _ tfsdk.ResourceWithImportState = terrainResource{}
+var _ tfsdk.ResourceWithModifyPlan = terrainResource{}
+
+type terrainResourceType struct{}
+
+func (t terrainResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Terrain raised from a grayscale heightmap image, built in layers of material with optional water below a sea level.",
+		Attributes: map[string]tfsdk.Attribute{
+			"path": {
+				MarkdownDescription: "Path to a local PNG (or JPEG) heightmap. Black is the lowest point and white the highest; colour images are converted to gray.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"position": {
+				MarkdownDescription: "The north-west corner of the terrain at its base Y. The top of the heightmap faces north.",
+				Required:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"x": {
+						MarkdownDescription: "X coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"y": {
+						MarkdownDescription: "Y coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"z": {
+						MarkdownDescription: "Z coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+				}),
+			},
+			"width": {
+				MarkdownDescription: "Size along X in blocks. The heightmap is scaled to fit.",
+				Required:            true,
+				Type:                types.NumberType,
+			},
+			"length": {
+				MarkdownDescription: "Size along Z in blocks. The heightmap is scaled to fit.",
+				Required:            true,
+				Type:                types.NumberType,
+			},
+			"max_height": {
+				MarkdownDescription: "How many blocks above the base Y a white pixel reaches. Black pixels give a single layer at the base.",
+				Required:            true,
+				Type:                types.NumberType,
+			},
+			"layers": {
+				MarkdownDescription: "Materials from the surface down. The last layer fills the rest of each column. Defaults to one block of grass, three of dirt, then stone.",
+				Optional:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"material": {
+						MarkdownDescription: "The block, e.g. `minecraft:sand`.",
+						Required:            true,
+						Type:                types.StringType,
+					},
+					"depth": {
+						MarkdownDescription: "Thickness in blocks. Ignored for the last layer.",
+						Optional:            true,
+						Type:                types.NumberType,
+					},
+				}),
+			},
+			"sea_level": {
+				MarkdownDescription: "When set, columns whose surface is below this Y are topped up with water to it.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"source_hash": {
+				MarkdownDescription: "SHA-256 of the heightmap file, so editing the file triggers an update. Cleared on refresh when `world_path` is set and the terrain no longer matches the world.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the terrain resource.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t terrainResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return terrainResource{provider: provider}, diags
+}
+
+type terrainResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Path      string       `tfsdk:"path"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+	Width     int `tfsdk:"width"`
+	Length    int `tfsdk:"length"`
+	MaxHeight int `tfsdk:"max_height"`
+	Layers    []struct {
+		Material string `tfsdk:"material"`
+		Depth    *int   `tfsdk:"depth"`
+	} `tfsdk:"layers"`
+	SeaLevel   *int         `tfsdk:"sea_level"`
+	SourceHash types.String `tfsdk:"source_hash"`
+}
+
+func (d terrainResourceData) terrain() minecraft.Terrain {
+	t := minecraft.Terrain{
+		Origin:    minecraft.Pos{X: d.Position.X, Y: d.Position.Y, Z: d.Position.Z},
+		Width:     d.Width,
+		Length:    d.Length,
+		MaxHeight: d.MaxHeight,
+	}
+	for _, layer := range d.Layers {
+		l := minecraft.TerrainLayer{Block: layer.Material}
+		if layer.Depth != nil {
+			l.Depth = *layer.Depth
+		}
+		t.Layers = append(t.Layers, l)
+	}
+	if d.SeaLevel != nil {
+		t.Water = true
+		t.SeaLevel = *d.SeaLevel
+	}
+	return t
+}
+
+// columns renders the heightmap into columns.
+func (d terrainResourceData) columns() (map[[2]int]minecraft.Column, error) {
+	t := d.terrain()
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	img, err := minecraft.LoadImage(d.Path)
+	if err != nil {
+		return nil, err
+	}
+	return t.Columns(img), nil
+}
+
+// placed returns the columns this state put in the world. If the heightmap
+// has changed since, the old surface is unknown, so the whole footprint up
+// to max_height is returned instead.
+func (d terrainResourceData) placed() map[[2]int]minecraft.Column {
+	if hash, err := fileHash(d.Path); err == nil && hash == d.SourceHash.Value {
+		if cols, err := d.columns(); err == nil {
+			return cols
+		}
+	}
+	return d.terrain().Footprint()
+}
+
+// diffColumns returns the columns to write to get from old to new: every
+// column that changed, with air above it if it got lower, plus air for
+// columns that are gone.
+func diffColumns(old, new map[[2]int]minecraft.Column) map[[2]int]minecraft.Column {
+	out := map[[2]int]minecraft.Column{}
+	for xz, col := range new {
+		prev, ok := old[xz]
+		if ok && prev.Equal(col) {
+			continue
+		}
+		if ok && prev.Top() > col.Top() {
+			col = append(append(minecraft.Column{}, col...), minecraft.Segment{From: col.Top() + 1, To: prev.Top(), Block: "minecraft:air"})
+		}
+		out[xz] = col
+	}
+	for xz, prev := range old {
+		if _, ok := new[xz]; !ok {
+			out[xz] = minecraft.Column{{From: prev[0].From, To: prev.Top(), Block: "minecraft:air"}}
+		}
+	}
+	return out
+}
+
+type terrainResource struct {
+	provider provider
+}
+
+func (r terrainResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data terrainResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to read heightmap, got error: %s", err))
+		return
+	}
+	cols, err := data.columns()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetColumns(ctx, cols); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to build terrain, got error: %s", err))
+		return
+	}
+
+	data.SourceHash = types.String{Value: hash}
+	data.Id = types.String{Value: fmt.Sprintf("terrain-%d-%d-%d", data.Position.X, data.Position.Y, data.Position.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read checks every column against the world files when world_path is set,
+// and clears source_hash if any of them was dug into or built on, so the
+// next apply rewrites the whole footprint.
+func (r terrainResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data terrainResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read terrain, got error: %s", err))
+		return
+	}
+	if hash, err := fileHash(data.Path); world != nil && err == nil && hash == data.SourceHash.Value {
+		if cols, err := data.columns(); err == nil {
+			blocks := map[minecraft.Pos]string{}
+			for xz, col := range cols {
+				for _, seg := range col {
+					for y := seg.From; y <= seg.To; y++ {
+						blocks[minecraft.Pos{X: xz[0], Y: y, Z: xz[1]}] = seg.Block
+					}
+				}
+			}
+			drifted, err := driftedBlocks(world, data.Dimension.Value, blocks)
+			if err != nil {
+				resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read terrain, got error: %s", err))
+				return
+			}
+			if len(drifted) > 0 {
+				data.SourceHash = types.String{Null: true}
+			}
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only rewrites the columns that differ between the old and new
+// terrain.
+func (r terrainResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, prior terrainResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to read heightmap, got error: %s", err))
+		return
+	}
+	cols, err := data.columns()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetColumns(ctx, diffColumns(prior.placed(), cols)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update terrain, got error: %s", err))
+		return
+	}
+
+	data.SourceHash = types.String{Value: hash}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r terrainResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data terrainResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetColumns(ctx, diffColumns(data.placed(), nil)); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete terrain, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan validates the terrain and records the heightmap hash so edits
+// to the file plan an update.
+func (r terrainResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data terrainResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.terrain().Validate(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	pathAttr := tftypes.NewAttributePath().WithAttributeName("path")
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(pathAttr, "Invalid Heightmap", err.Error())
+		return
+	}
+	if _, err := minecraft.LoadImage(data.Path); err != nil {
+		resp.Diagnostics.AddAttributeError(pathAttr, "Invalid Heightmap", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("source_hash"), types.String{Value: hash})...)
+}
+
+func (r terrainResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
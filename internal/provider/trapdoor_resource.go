@@ -0,0 +1,269 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = trapdoorResourceType{}
+var _ tfsdk.Resource = trapdoorResource{}
+var _ tfsdk.ResourceWithImportState = trapdoorResource{}
+var _ tfsdk.ResourceWithModifyPlan = trapdoorResource{}
+
+type trapdoorResourceType struct{}
+
+func (t trapdoorResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "A Minecraft trapdoor (e.g., minecraft:oak_trapdoor) with orientation.",
+		Attributes: map[string]tfsdk.Attribute{
+			"material": {
+				MarkdownDescription: "The trapdoor material, e.g. `minecraft:oak_trapdoor`, `minecraft:iron_trapdoor`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"position": {
+				MarkdownDescription: "The position of the trapdoor.",
+				Required:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"x": {
+						MarkdownDescription: "X coordinate of the block",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+					"y": {
+						MarkdownDescription: "Y coordinate of the block",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+					"z": {
+						MarkdownDescription: "Z coordinate of the block",
+						Type:                types.NumberType,
+						Required:            true,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.RequiresReplace(),
+						},
+					},
+				}),
+			},
+			"facing": {
+				MarkdownDescription: "Direction the trapdoor faces: one of `north`, `south`, `east`, `west`. The hinge is on the opposite edge.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"half": {
+				MarkdownDescription: "Whether the trapdoor sits in the `top` or `bottom` half of the block. Defaults to `bottom`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"open": {
+				MarkdownDescription: "Whether the trapdoor is open. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"powered": {
+				MarkdownDescription: "Whether the trapdoor is powered by redstone. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"waterlogged": {
+				MarkdownDescription: "Whether the trapdoor is waterlogged. Defaults to false.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the block",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t trapdoorResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return trapdoorResource{provider: provider}, diags
+}
+
+type trapdoorResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Material  string       `tfsdk:"material"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+	Facing      string       `tfsdk:"facing"`      // north|south|east|west
+	Half        types.String `tfsdk:"half"`        // top|bottom
+	Open        *bool        `tfsdk:"open"`        // optional
+	Powered     *bool        `tfsdk:"powered"`     // optional
+	Waterlogged *bool        `tfsdk:"waterlogged"` // optional
+}
+
+func (d trapdoorResourceData) state() map[string]string {
+	return map[string]string{
+		"facing":      d.Facing,
+		"half":        stringState(d.Half, "bottom"),
+		"open":        boolState(d.Open),
+		"powered":     boolState(d.Powered),
+		"waterlogged": boolState(d.Waterlogged),
+	}
+}
+
+type trapdoorResource struct {
+	provider provider
+}
+
+func (r trapdoorResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data trapdoorResourceData
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	err = client.CreateBlock(ctx, minecraft.BlockWithState(data.Material, data.state()), data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create trapdoor, got error: %s", err))
+		return
+	}
+
+	data.Id = types.String{Value: fmt.Sprintf("trapdoor-%d-%d-%d", data.Position.X, data.Position.Y, data.Position.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r trapdoorResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data trapdoorResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	exists, err := client.TestBlock(ctx, data.Material, data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read trapdoor, got error: %s", err))
+		return
+	}
+	if !exists {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	registry, _ := r.provider.BlockStates()
+	current, err := client.GetBlockState(ctx, data.Material, stateCandidates(registry, data.Material, data.state()), data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read trapdoor state, got error: %s", err))
+		return
+	}
+	if facing, ok := current["facing"]; ok {
+		data.Facing = facing
+	}
+	data.Half = readStringState(data.Half, current["half"], "bottom")
+	data.Open = readBoolState(data.Open, current["open"])
+	data.Powered = readBoolState(data.Powered, current["powered"])
+	data.Waterlogged = readBoolState(data.Waterlogged, current["waterlogged"])
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r trapdoorResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data trapdoorResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	err = client.CreateBlock(ctx, minecraft.BlockWithState(data.Material, data.state()), data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update trapdoor, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r trapdoorResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data trapdoorResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	err = client.DeleteBlock(ctx, data.Position.X, data.Position.Y, data.Position.Z)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete block, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan checks the material and block state against the registry.
+func (r trapdoorResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data trapdoorResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+
+	checkBlockState(&resp.Diagnostics, registry, minecraft.NormalizeMaterial(data.Material), "_trapdoor", data.state())
+}
+
+func (r trapdoorResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
@@ -0,0 +1,454 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = voxelModelResourceType{}
+var _ tfsdk.Resource = voxelModelResource{}
+var _ tfsdk.ResourceWithImportState = voxelModelResource{}
+var _ tfsdk.ResourceWithModifyPlan = voxelModelResource{}
+
+type voxelModelResourceType struct{}
+
+// sizeAttrTypes are the attributes of a computed `size` object.
+var sizeAttrTypes = map[string]attr.Type{
+	"x": types.Int64Type,
+	"y": types.Int64Type,
+	"z": types.Int64Type,
+}
+
+func sizeObject(size minecraft.Pos) types.Object {
+	return types.Object{
+		AttrTypes: sizeAttrTypes,
+		Attrs: map[string]attr.Value{
+			"x": types.Int64{Value: int64(size.X)},
+			"y": types.Int64{Value: int64(size.Y)},
+			"z": types.Int64{Value: int64(size.Z)},
+		},
+	}
+}
+
+// objectSize reads a `size` object back. It returns false if the object is
+// null or unknown.
+func objectSize(o types.Object) (minecraft.Pos, bool) {
+	if o.Null || o.Unknown {
+		return minecraft.Pos{}, false
+	}
+	var size [3]int
+	for i, k := range []string{"x", "y", "z"} {
+		v, ok := o.Attrs[k].(types.Int64)
+		if !ok || v.Null || v.Unknown {
+			return minecraft.Pos{}, false
+		}
+		size[i] = int(v.Value)
+	}
+	return minecraft.Pos{X: size[0], Y: size[1], Z: size[2]}, true
+}
+
+func (t voxelModelResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "A MagicaVoxel `.vox` model built out of blocks.",
+		Attributes: map[string]tfsdk.Attribute{
+			"path": {
+				MarkdownDescription: "Path to a local `.vox` file. Files with several models are assembled using their scene graph.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"position": {
+				MarkdownDescription: "Where the north-west bottom corner of the model goes.",
+				Required:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"x": {
+						MarkdownDescription: "X coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"y": {
+						MarkdownDescription: "Y coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"z": {
+						MarkdownDescription: "Z coordinate",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+				}),
+			},
+			"rotation": {
+				MarkdownDescription: "Degrees to turn the model clockwise seen from above: `0`, `90`, `180` or `270`. Defaults to `0`.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"mirror": {
+				MarkdownDescription: "Flip the model before rotating it: `none`, `x` (east-west) or `z` (north-south). Defaults to `none`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"materials": {
+				MarkdownDescription: "Blocks keyed by MagicaVoxel palette index (1-255), e.g. `{ \"1\" = \"minecraft:oak_planks\" }`. Voxels mapped to `minecraft:air` are left out.",
+				Optional:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+			},
+			"palette": {
+				MarkdownDescription: "Blocks to match the colour of voxels missing from `materials`: `wool`, `concrete` or `terracotta`. Ignored when `custom_palette` is set. Defaults to `wool`.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"custom_palette": {
+				MarkdownDescription: "Blocks to match the colour of voxels missing from `materials`, mapped to their colour as `#rrggbb`.",
+				Optional:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+			},
+			"voxel_count": {
+				MarkdownDescription: "Number of blocks the model places.",
+				Computed:            true,
+				Type:                types.Int64Type,
+			},
+			"size": {
+				MarkdownDescription: "Size of the placed model in blocks along X, Y and Z, after rotation.",
+				Computed:            true,
+				Type:                types.ObjectType{AttrTypes: sizeAttrTypes},
+			},
+			"source_hash": {
+				MarkdownDescription: "SHA-256 of the model file, so editing the file triggers an update. Refresh clears it when `world_path` is set and voxels were changed in game.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the voxel model resource.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t voxelModelResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return voxelModelResource{provider: provider}, diags
+}
+
+type voxelModelResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Path      string       `tfsdk:"path"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+	Rotation      *int              `tfsdk:"rotation"`
+	Mirror        types.String      `tfsdk:"mirror"`
+	Materials     map[string]string `tfsdk:"materials"`
+	Palette       types.String      `tfsdk:"palette"`
+	CustomPalette map[string]string `tfsdk:"custom_palette"`
+	VoxelCount    types.Int64       `tfsdk:"voxel_count"`
+	Size          types.Object      `tfsdk:"size"`
+	SourceHash    types.String      `tfsdk:"source_hash"`
+}
+
+func (d voxelModelResourceData) transform() minecraft.Transform {
+	t := minecraft.Transform{Mirror: d.Mirror.Value}
+	if d.Rotation != nil {
+		t.Rotation = *d.Rotation
+	}
+	return t
+}
+
+// materials parses the palette index => block map.
+func (d voxelModelResourceData) materials() ([256]string, error) {
+	var out [256]string
+	for key, block := range d.Materials {
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 1 || i > 255 {
+			return out, fmt.Errorf("materials keys must be palette indexes from 1 to 255 (got %q)", key)
+		}
+		out[i] = block
+	}
+	return out, nil
+}
+
+func (d voxelModelResourceData) validate() error {
+	if err := d.transform().Validate(); err != nil {
+		return err
+	}
+	if _, err := d.materials(); err != nil {
+		return err
+	}
+	_, err := colorPalette(d.Palette, d.CustomPalette)
+	return err
+}
+
+// blocks builds the model into world blocks and returns them with the size
+// of the placed model.
+func (d voxelModelResourceData) blocks() (map[minecraft.Pos]string, minecraft.Pos, error) {
+	if err := d.validate(); err != nil {
+		return nil, minecraft.Pos{}, err
+	}
+	materials, _ := d.materials()
+	palette, _ := colorPalette(d.Palette, d.CustomPalette)
+	t := d.transform()
+	origin := d.origin()
+
+	model, err := minecraft.ReadVox(d.Path)
+	if err != nil {
+		return nil, minecraft.Pos{}, err
+	}
+
+	for i := 1; i < 256; i++ {
+		if materials[i] == "" {
+			c := model.Palette[i]
+			materials[i] = palette.Nearest(float64(c.R), float64(c.G), float64(c.B)).Block
+		}
+	}
+
+	blocks := make(map[minecraft.Pos]string, len(model.Voxels))
+	for p, index := range model.Voxels {
+		block := materials[index]
+		if block == "minecraft:air" {
+			continue
+		}
+		q := t.Apply(p, model.Size)
+		blocks[minecraft.Pos{X: origin.X + q.X, Y: origin.Y + q.Y, Z: origin.Z + q.Z}] = block
+	}
+	return blocks, t.Size(model.Size), nil
+}
+
+// clearFootprint fills the box a build of the given size occupied at
+// origin with air, for when its source file has changed and the old blocks
+// can't be worked out.
+func clearFootprint(ctx context.Context, client *minecraft.Client, origin minecraft.Pos, size types.Object) error {
+	extent, ok := objectSize(size)
+	if !ok || extent.X < 1 || extent.Y < 1 || extent.Z < 1 {
+		return nil
+	}
+	box := minecraft.Box{
+		Min:   origin,
+		Max:   minecraft.Pos{X: origin.X + extent.X - 1, Y: origin.Y + extent.Y - 1, Z: origin.Z + extent.Z - 1},
+		Block: "minecraft:air",
+	}
+	for _, b := range minecraft.SplitBox(box) {
+		if err := client.FillBox(ctx, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d voxelModelResourceData) origin() minecraft.Pos {
+	return minecraft.Pos{X: d.Position.X, Y: d.Position.Y, Z: d.Position.Z}
+}
+
+// placed returns the blocks this state put in the world, or false if the
+// model file has changed since.
+func (d voxelModelResourceData) placed() (map[minecraft.Pos]string, bool) {
+	hash, err := fileHash(d.Path)
+	if err != nil || hash != d.SourceHash.Value {
+		return nil, false
+	}
+	blocks, _, err := d.blocks()
+	if err != nil {
+		return nil, false
+	}
+	return blocks, true
+}
+
+type voxelModelResource struct {
+	provider provider
+}
+
+func (r voxelModelResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data voxelModelResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to read model, got error: %s", err))
+		return
+	}
+	blocks, size, err := data.blocks()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, blocks); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place model, got error: %s", err))
+		return
+	}
+
+	data.VoxelCount = types.Int64{Value: int64(len(blocks))}
+	data.Size = sizeObject(size)
+	data.SourceHash = types.String{Value: hash}
+	data.Id = types.String{Value: fmt.Sprintf("voxel-model-%d-%d-%d", data.Position.X, data.Position.Y, data.Position.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read looks the voxels up in the world files when world_path is set, and
+// clears source_hash if any was changed in game so the model is built again.
+func (r voxelModelResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data voxelModelResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read voxel model, got error: %s", err))
+		return
+	}
+	if blocks, ok := data.placed(); world != nil && ok {
+		drifted, err := driftedBlocks(world, data.Dimension.Value, blocks)
+		if err != nil {
+			resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read voxel model, got error: %s", err))
+			return
+		}
+		if len(drifted) > 0 {
+			data.SourceHash = types.String{Null: true}
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only rewrites the blocks that differ between the old and new model.
+// If the model file itself changed, the old footprint is cleared first.
+func (r voxelModelResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, prior voxelModelResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("Unable to read model, got error: %s", err))
+		return
+	}
+	blocks, size, err := data.blocks()
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	changes := blocks
+	if old, ok := prior.placed(); ok {
+		changes = diffBlocks(old, blocks)
+	} else if err := clearFootprint(ctx, client, prior.origin(), prior.Size); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clear old model, got error: %s", err))
+		return
+	}
+
+	if _, err := client.SetBlocks(ctx, changes); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update model, got error: %s", err))
+		return
+	}
+
+	data.VoxelCount = types.Int64{Value: int64(len(blocks))}
+	data.Size = sizeObject(size)
+	data.SourceHash = types.String{Value: hash}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r voxelModelResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data voxelModelResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if old, ok := data.placed(); ok {
+		_, err = client.SetBlocks(ctx, diffBlocks(old, nil))
+	} else {
+		err = clearFootprint(ctx, client, data.origin(), data.Size)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete model, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan validates the model and works out voxel_count, size and the
+// file hash so they are known at plan time.
+func (r voxelModelResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data voxelModelResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.validate(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+
+	pathAttr := tftypes.NewAttributePath().WithAttributeName("path")
+	hash, err := fileHash(data.Path)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(pathAttr, "Invalid Model", err.Error())
+		return
+	}
+	blocks, size, err := data.blocks()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(pathAttr, "Invalid Model", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("voxel_count"), types.Int64{Value: int64(len(blocks))})...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("size"), sizeObject(size))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("source_hash"), types.String{Value: hash})...)
+}
+
+func (r voxelModelResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}
@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = weatherResourceType{}
+var _ tfsdk.Resource = weatherResource{}
+var _ tfsdk.ResourceWithImportState = weatherResource{}
+var _ tfsdk.ResourceWithModifyPlan = weatherResource{}
+
+// weatherCycleRule is the gamerule that lets the weather change by itself.
+const weatherCycleRule = "doWeatherCycle"
+
+type weatherResourceType struct{}
+
+func (t weatherResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Set the **weather** (wraps `/weather`), optionally locking it by turning off the `doWeatherCycle` gamerule. The previous weather and rule are put back on destroy.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"weather": {
+				MarkdownDescription: "Weather to set: `clear`, `rain` or `thunder`.",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"duration": {
+				MarkdownDescription: "Seconds until the weather may change again. Defaults to a random time chosen by the game. Has no effect while `locked`.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"locked": {
+				MarkdownDescription: "Keep the weather by turning off `doWeatherCycle`. Defaults to `false`.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"previous_weather": {
+				MarkdownDescription: "Best-effort snapshot of the weather before it was first set, put back on destroy. Empty when it could not be read: reading the weather needs `world_path` or Minecraft 1.20.5 or later.",
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+			"previous_weather_cycle": {
+				MarkdownDescription: "Value of `doWeatherCycle` before the weather was first locked, put back on unlock and on destroy.",
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.BoolType,
+			},
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the weather, always `default`.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t weatherResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return weatherResource{provider: provider}, diags
+}
+
+type weatherResourceData struct {
+	Id                   types.String `tfsdk:"id"`
+	Weather              string       `tfsdk:"weather"`
+	Duration             *int         `tfsdk:"duration"`
+	Locked               types.Bool   `tfsdk:"locked"`
+	PreviousWeather      types.String `tfsdk:"previous_weather"`
+	PreviousWeatherCycle types.Bool   `tfsdk:"previous_weather_cycle"`
+}
+
+func (d weatherResourceData) duration() int {
+	if d.Duration == nil {
+		return 0
+	}
+	return *d.Duration
+}
+
+type weatherResource struct {
+	provider provider
+}
+
+// currentWeather reads the weather from the world files when world_path is
+// set, and over RCON otherwise. It returns "" when neither can be used.
+func (r weatherResource) currentWeather(ctx context.Context, client *minecraft.Client) (string, error) {
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		return "", err
+	}
+	if world != nil {
+		// The weather may have changed earlier in this run.
+		if err := client.SaveAll(ctx); err != nil {
+			return "", err
+		}
+		level, err := world.Level()
+		if err != nil {
+			return "", err
+		}
+		return minecraft.WeatherFromLevel(level), nil
+	}
+
+	version := r.provider.ServerVersion()
+	if !minecraft.WeatherQuerySupported(version) {
+		return "", nil
+	}
+	return client.GetWeather(ctx, version)
+}
+
+// weatherCycle reads doWeatherCycle.
+func weatherCycle(ctx context.Context, client *minecraft.Client) (bool, error) {
+	raw, err := client.GetGameRule(ctx, weatherCycleRule)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(raw) == "true", nil
+}
+
+// lockWeather turns off doWeatherCycle, first recording its value in
+// data.PreviousWeatherCycle unless an earlier lock already did.
+func lockWeather(ctx context.Context, client *minecraft.Client, data *weatherResourceData) error {
+	if data.PreviousWeatherCycle.Unknown || data.PreviousWeatherCycle.Null {
+		cycle, err := weatherCycle(ctx, client)
+		if err != nil {
+			return err
+		}
+		data.PreviousWeatherCycle = types.Bool{Value: cycle}
+	}
+	return client.SetGameRuleBool(ctx, weatherCycleRule, false)
+}
+
+// unlockWeather puts doWeatherCycle back to its value before the lock, or
+// on when that is not known.
+func unlockWeather(ctx context.Context, client *minecraft.Client, data weatherResourceData) error {
+	cycle := true
+	if !data.PreviousWeatherCycle.Unknown && !data.PreviousWeatherCycle.Null {
+		cycle = data.PreviousWeatherCycle.Value
+	}
+	return client.SetGameRuleBool(ctx, weatherCycleRule, cycle)
+}
+
+func (r weatherResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data weatherResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	// Snapshot the previous weather (best effort)
+	previous, err := r.currentWeather(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Snapshot Warning", fmt.Sprintf("Unable to read the current weather, so it will not be restored on destroy: %s", err))
+	}
+	data.PreviousWeather = types.String{Value: previous}
+
+	if data.Locked.Value {
+		if err := lockWeather(ctx, client, &data); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to lock weather, got error: %s", err))
+			return
+		}
+	} else {
+		data.PreviousWeatherCycle = types.Bool{Null: true}
+	}
+
+	if err := client.SetWeather(ctx, r.provider.ServerVersion(), data.Weather, data.duration()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set weather, got error: %s", err))
+		return
+	}
+
+	data.Id = types.String{Value: "default"}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read checks a locked weather is still in place: an unlocked weather
+// changes by itself, so it is not compared.
+func (r weatherResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data weatherResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Locked.Value {
+		client, err := r.provider.GetClient(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+			return
+		}
+
+		cycle, err := weatherCycle(ctx, client)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read gamerule %q, got error: %s", weatherCycleRule, err))
+			return
+		}
+		if cycle {
+			data.Locked = types.Bool{Value: false}
+		}
+
+		weather, err := r.currentWeather(ctx, client)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read weather, got error: %s", err))
+			return
+		}
+		if weather != "" {
+			data.Weather = weather
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r weatherResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, state weatherResourceData
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	switch {
+	case data.Locked.Value:
+		// Also turns the cycle off again if it was turned on in game.
+		if err := lockWeather(ctx, client, &data); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to lock weather, got error: %s", err))
+			return
+		}
+	case state.Locked.Value:
+		// The plan no longer holds the recorded value, see ModifyPlan.
+		if err := unlockWeather(ctx, client, state); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unlock weather, got error: %s", err))
+			return
+		}
+		// Restored, so a later lock records the value afresh.
+		data.PreviousWeatherCycle = types.Bool{Null: true}
+	}
+	if data.PreviousWeatherCycle.Unknown {
+		data.PreviousWeatherCycle = types.Bool{Null: true}
+	}
+
+	if err := client.SetWeather(ctx, r.provider.ServerVersion(), data.Weather, data.duration()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set weather, got error: %s", err))
+		return
+	}
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete puts back the weather and doWeatherCycle recorded before the first
+// apply.
+func (r weatherResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data weatherResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if previous := data.PreviousWeather.Value; previous != "" {
+		if err := client.SetWeather(ctx, r.provider.ServerVersion(), previous, 0); err != nil {
+			resp.Diagnostics.AddWarning("Restore Warning", fmt.Sprintf("Failed to restore weather to %q: %s", previous, err))
+		}
+	}
+	if data.Locked.Value {
+		if err := unlockWeather(ctx, client, data); err != nil {
+			resp.Diagnostics.AddWarning("Restore Warning", fmt.Sprintf("Failed to restore gamerule %q: %s", weatherCycleRule, err))
+		}
+	}
+}
+
+// ModifyPlan checks the weather and duration, and plans to forget
+// previous_weather_cycle when the weather is unlocked.
+func (r weatherResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data weatherResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := minecraft.ValidateWeather(data.Weather); err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("weather"), "Validation Error", err.Error())
+	}
+	if data.Duration != nil && *data.Duration < 0 {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("duration"), "Validation Error", "The duration must not be negative.")
+	}
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+	var state weatherResourceData
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if state.Locked.Value && !data.Locked.Value {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("previous_weather_cycle"), types.Bool{Null: true})...)
+	}
+}
+
+// ImportState adopts the current weather, locked if doWeatherCycle is off.
+// Nothing is recorded to restore on destroy.
+func (r weatherResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	weather, err := r.currentWeather(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to read weather, got error: %s", err))
+		return
+	}
+	if weather == "" {
+		resp.Diagnostics.AddError("Import Error", "Reading the weather needs `world_path` or Minecraft 1.20.5 or later.")
+		return
+	}
+	cycle, err := weatherCycle(ctx, client)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to read gamerule %q, got error: %s", weatherCycleRule, err))
+		return
+	}
+
+	locked := types.Bool{Null: true}
+	if !cycle {
+		locked = types.Bool{Value: true}
+	}
+
+	data := weatherResourceData{
+		Id:                   types.String{Value: "default"},
+		Weather:              weather,
+		Locked:               locked,
+		PreviousWeather:      types.String{Value: ""},
+		PreviousWeatherCycle: types.Bool{Null: true},
+	}
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
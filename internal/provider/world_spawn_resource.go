@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = worldSpawnResourceType{}
+var _ tfsdk.Resource = worldSpawnResource{}
+var _ tfsdk.ResourceWithImportState = worldSpawnResource{}
+
+// spawnPosition is the required block position of a spawn, which can be
+// moved in place.
+func spawnPosition(description string) tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: description,
+		Required:            true,
+		Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+			"x": {
+				MarkdownDescription: "X coordinate.",
+				Type:                types.NumberType,
+				Required:            true,
+			},
+			"y": {
+				MarkdownDescription: "Y coordinate.",
+				Type:                types.NumberType,
+				Required:            true,
+			},
+			"z": {
+				MarkdownDescription: "Z coordinate.",
+				Type:                types.NumberType,
+				Required:            true,
+			},
+		}),
+	}
+}
+
+// spawnAngle is the optional yaw players face when they spawn.
+func spawnAngle() tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: "Direction players face when they spawn, in degrees: `0` is south, `90` west, `180` north and `-90` east. Defaults to `0`.",
+		Optional:            true,
+		Type:                types.NumberType,
+	}
+}
+
+type spawnPositionData struct {
+	X int `tfsdk:"x"`
+	Y int `tfsdk:"y"`
+	Z int `tfsdk:"z"`
+}
+
+func spawnPositionOf(p minecraft.Pos) spawnPositionData {
+	return spawnPositionData{X: p.X, Y: p.Y, Z: p.Z}
+}
+
+func (p spawnPositionData) pos() minecraft.Pos {
+	return minecraft.Pos{X: p.X, Y: p.Y, Z: p.Z}
+}
+
+// readSpawnAngle records the angle read from the game, leaving an unset
+// angle unset while it is still the default. The game stores angles as
+// 32-bit floats, so small differences are rounding.
+func readSpawnAngle(current *float64, angle float64) *float64 {
+	if current == nil && angle == 0 {
+		return nil
+	}
+	if current != nil && math.Abs(*current-angle) < 0.001 {
+		return current
+	}
+	return &angle
+}
+
+func angleValue(angle *float64) float64 {
+	if angle == nil {
+		return 0
+	}
+	return *angle
+}
+
+type worldSpawnResourceType struct{}
+
+func (t worldSpawnResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Set the **world spawn** (wraps `/setworldspawn`), where players without a spawnpoint of their own appear.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"position": spawnPosition("Block players spawn at, in the overworld. Players spawn randomly within `spawnRadius` blocks of it in survival and adventure mode."),
+			"angle":    spawnAngle(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the world spawn, always `default`.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t worldSpawnResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return worldSpawnResource{provider: provider}, diags
+}
+
+type worldSpawnResourceData struct {
+	Id       types.String      `tfsdk:"id"`
+	Position spawnPositionData `tfsdk:"position"`
+	Angle    *float64          `tfsdk:"angle"`
+}
+
+type worldSpawnResource struct {
+	provider provider
+}
+
+func (r worldSpawnResource) set(ctx context.Context, data worldSpawnResourceData, diags *diag.Diagnostics) {
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+	if err := client.SetWorldSpawn(ctx, data.Position.pos(), angleValue(data.Angle)); err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to set world spawn, got error: %s", err))
+	}
+}
+
+func (r worldSpawnResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data worldSpawnResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = types.String{Value: "default"}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read records changes made in game from level.dat. Without world_path the
+// world spawn cannot be read, and the state is kept as is.
+func (r worldSpawnResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data worldSpawnResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to open world, got error: %s", err))
+		return
+	}
+	if world != nil {
+		level, err := world.Level()
+		if err != nil {
+			resp.Diagnostics.AddError("World Error", fmt.Sprintf("Unable to read level.dat, got error: %s", err))
+			return
+		}
+		spawn := minecraft.WorldSpawnFromLevel(level)
+		data.Position = spawnPositionOf(spawn.Pos)
+		data.Angle = readSpawnAngle(data.Angle, spawn.Angle)
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r worldSpawnResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data worldSpawnResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.set(ctx, data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete leaves the world spawn where it is: every world has one.
+func (r worldSpawnResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data worldSpawnResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ImportState adopts the current world spawn, read from level.dat.
+func (r worldSpawnResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to open world, got error: %s", err))
+		return
+	}
+	if world == nil {
+		resp.Diagnostics.AddError("Import Error", "Reading the world spawn needs `world_path` to be set.")
+		return
+	}
+	level, err := world.Level()
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to read level.dat, got error: %s", err))
+		return
+	}
+
+	spawn := minecraft.WorldSpawnFromLevel(level)
+	data := worldSpawnResourceData{
+		Id:       types.String{Value: "default"},
+		Position: spawnPositionOf(spawn.Pos),
+		Angle:    readSpawnAngle(nil, spawn.Angle),
+	}
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,364 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = worldborderResourceType{}
+var _ tfsdk.Resource = worldborderResource{}
+var _ tfsdk.ResourceWithImportState = worldborderResource{}
+var _ tfsdk.ResourceWithModifyPlan = worldborderResource{}
+
+// maxWorldBorderSize is the widest border the game allows.
+const maxWorldBorderSize = 59999968
+
+type worldborderResourceType struct{}
+
+func (t worldborderResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Manage the **world border** (wraps `/worldborder`). Only the settings given are managed; destroying the resource puts them back to the defaults of a new world.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"center": {
+				MarkdownDescription: "Centre of the border. Defaults to `0, 0` on destroy.",
+				Optional:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"x": {
+						MarkdownDescription: "X coordinate.",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+					"z": {
+						MarkdownDescription: "Z coordinate.",
+						Type:                types.NumberType,
+						Required:            true,
+					},
+				}),
+			},
+			"size": {
+				MarkdownDescription: fmt.Sprintf("Width of the border in blocks, from 1 to %d.", maxWorldBorderSize),
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"lerp_duration": {
+				MarkdownDescription: "Seconds over which the border grows or shrinks to a new `size`. Defaults to `0`, an immediate change.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"damage_amount": {
+				MarkdownDescription: "Damage per second for each block a player is beyond `damage_buffer`. The game's default is `0.2`.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"damage_buffer": {
+				MarkdownDescription: "Blocks players can go outside the border before taking damage. The game's default is `5`.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"warning_distance": {
+				MarkdownDescription: "Blocks from the border at which players see a warning. The game's default is `5`.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"warning_time": {
+				MarkdownDescription: "Seconds before a shrinking border reaches players that they see a warning. The game's default is `15`.",
+				Optional:            true,
+				Type:                types.NumberType,
+			},
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the world border, always `worldborder`.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t worldborderResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return worldborderResource{provider: provider}, diags
+}
+
+type worldborderCenter struct {
+	X float64 `tfsdk:"x"`
+	Z float64 `tfsdk:"z"`
+}
+
+type worldborderResourceData struct {
+	Id              types.String       `tfsdk:"id"`
+	Center          *worldborderCenter `tfsdk:"center"`
+	Size            *float64           `tfsdk:"size"`
+	LerpDuration    *int               `tfsdk:"lerp_duration"`
+	DamageAmount    *float64           `tfsdk:"damage_amount"`
+	DamageBuffer    *float64           `tfsdk:"damage_buffer"`
+	WarningDistance *int               `tfsdk:"warning_distance"`
+	WarningTime     *int               `tfsdk:"warning_time"`
+}
+
+type worldborderResource struct {
+	provider provider
+}
+
+// apply sends the settings in data that differ from prior, which is nil on
+// create.
+func (r worldborderResource) apply(ctx context.Context, data worldborderResourceData, prior *worldborderResourceData) error {
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	if prior == nil {
+		prior = &worldborderResourceData{}
+	}
+
+	if data.Center != nil && (prior.Center == nil || *data.Center != *prior.Center) {
+		if err := client.SetWorldBorderCenter(ctx, data.Center.X, data.Center.Z); err != nil {
+			return err
+		}
+	}
+	if data.Size != nil && (prior.Size == nil || *data.Size != *prior.Size) {
+		lerp := 0
+		if data.LerpDuration != nil {
+			lerp = *data.LerpDuration
+		}
+		if err := client.SetWorldBorderSize(ctx, *data.Size, lerp); err != nil {
+			return err
+		}
+	}
+	if data.DamageAmount != nil && (prior.DamageAmount == nil || *data.DamageAmount != *prior.DamageAmount) {
+		if err := client.SetWorldBorderDamageAmount(ctx, *data.DamageAmount); err != nil {
+			return err
+		}
+	}
+	if data.DamageBuffer != nil && (prior.DamageBuffer == nil || *data.DamageBuffer != *prior.DamageBuffer) {
+		if err := client.SetWorldBorderDamageBuffer(ctx, *data.DamageBuffer); err != nil {
+			return err
+		}
+	}
+	if data.WarningDistance != nil && (prior.WarningDistance == nil || *data.WarningDistance != *prior.WarningDistance) {
+		if err := client.SetWorldBorderWarningDistance(ctx, *data.WarningDistance); err != nil {
+			return err
+		}
+	}
+	if data.WarningTime != nil && (prior.WarningTime == nil || *data.WarningTime != *prior.WarningTime) {
+		if err := client.SetWorldBorderWarningTime(ctx, *data.WarningTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// current reads the border, and whether every setting was read. Without
+// world files only the size can be read.
+func (r worldborderResource) current(ctx context.Context) (minecraft.WorldBorder, bool, error) {
+	world, err := r.provider.World(ctx)
+	if err != nil {
+		return minecraft.WorldBorder{}, false, err
+	}
+	if world != nil {
+		level, err := world.Level()
+		if err != nil {
+			return minecraft.WorldBorder{}, false, err
+		}
+		return minecraft.WorldBorderFromLevel(level), true, nil
+	}
+
+	client, err := r.provider.GetClient(ctx)
+	if err != nil {
+		return minecraft.WorldBorder{}, false, err
+	}
+	size, err := client.GetWorldBorderSize(ctx)
+	if err != nil {
+		return minecraft.WorldBorder{}, false, err
+	}
+	return minecraft.WorldBorder{Size: size}, false, nil
+}
+
+func (r worldborderResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data worldborderResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, data, nil); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set world border, got error: %s", err))
+		return
+	}
+
+	data.Id = types.String{Value: "worldborder"}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read records changes made in game to the managed settings. The game
+// reports the size rounded to a whole block, so sizes within half a block
+// of the configured one count as unchanged. Without world files a border
+// that is still moving to its target reports a size in between, so the
+// size is only read when lerp_duration is not set.
+func (r worldborderResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data worldborderResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	border, all, err := r.current(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read world border, got error: %s", err))
+		return
+	}
+
+	lerping := !all && data.LerpDuration != nil && *data.LerpDuration > 0
+	if data.Size != nil && !lerping && math.Abs(*data.Size-border.Size) > 0.5 {
+		data.Size = &border.Size
+	}
+	if all {
+		if data.Center != nil {
+			data.Center = &worldborderCenter{X: border.CenterX, Z: border.CenterZ}
+		}
+		if data.DamageAmount != nil {
+			data.DamageAmount = &border.DamageAmount
+		}
+		if data.DamageBuffer != nil {
+			data.DamageBuffer = &border.DamageBuffer
+		}
+		if data.WarningDistance != nil {
+			data.WarningDistance = &border.WarningDistance
+		}
+		if data.WarningTime != nil {
+			data.WarningTime = &border.WarningTime
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r worldborderResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data, prior worldborderResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &prior)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, data, &prior); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set world border, got error: %s", err))
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete puts the managed settings back to the defaults of a new world.
+func (r worldborderResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data worldborderResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d := minecraft.DefaultWorldBorder
+	var defaults worldborderResourceData
+	if data.Center != nil {
+		defaults.Center = &worldborderCenter{X: d.CenterX, Z: d.CenterZ}
+	}
+	if data.Size != nil {
+		defaults.Size = &d.Size
+	}
+	if data.DamageAmount != nil {
+		defaults.DamageAmount = &d.DamageAmount
+	}
+	if data.DamageBuffer != nil {
+		defaults.DamageBuffer = &d.DamageBuffer
+	}
+	if data.WarningDistance != nil {
+		defaults.WarningDistance = &d.WarningDistance
+	}
+	if data.WarningTime != nil {
+		defaults.WarningTime = &d.WarningTime
+	}
+
+	if err := r.apply(ctx, defaults, nil); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to reset world border, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan checks the settings are in the ranges the game accepts.
+func (r worldborderResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data worldborderResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invalid := func(attribute, detail string) {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName(attribute), "Validation Error", detail)
+	}
+	if data.Size != nil && (*data.Size < 1 || *data.Size > maxWorldBorderSize) {
+		invalid("size", fmt.Sprintf("The size must be between 1 and %d (got %g).", maxWorldBorderSize, *data.Size))
+	}
+	if data.LerpDuration != nil && *data.LerpDuration < 0 {
+		invalid("lerp_duration", "The lerp duration must not be negative.")
+	}
+	if data.DamageAmount != nil && *data.DamageAmount < 0 {
+		invalid("damage_amount", "The damage amount must not be negative.")
+	}
+	if data.DamageBuffer != nil && *data.DamageBuffer < 0 {
+		invalid("damage_buffer", "The damage buffer must not be negative.")
+	}
+	if data.WarningDistance != nil && *data.WarningDistance < 0 {
+		invalid("warning_distance", "The warning distance must not be negative.")
+	}
+	if data.WarningTime != nil && *data.WarningTime < 0 {
+		invalid("warning_time", "The warning time must not be negative.")
+	}
+}
+
+// ImportState adopts the current border. Without world_path only the size
+// can be read, so the other settings start unmanaged.
+func (r worldborderResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	border, all, err := r.current(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Import Error", fmt.Sprintf("Unable to read world border, got error: %s", err))
+		return
+	}
+
+	data := worldborderResourceData{
+		Id:   types.String{Value: "worldborder"},
+		Size: &border.Size,
+	}
+	if all {
+		data.Center = &worldborderCenter{X: border.CenterX, Z: border.CenterZ}
+		data.DamageAmount = &border.DamageAmount
+		data.DamageBuffer = &border.DamageBuffer
+		data.WarningDistance = &border.WarningDistance
+		data.WarningTime = &border.WarningTime
+	}
+
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,377 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicraft/terraform-provider-minecraft/internal/minecraft"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ tfsdk.ResourceType = worldgenPlacementResourceType{}
+var _ tfsdk.Resource = worldgenPlacementResource{}
+var _ tfsdk.ResourceWithImportState = worldgenPlacementResource{}
+var _ tfsdk.ResourceWithModifyPlan = worldgenPlacementResource{}
+
+// Default radius of the area recorded around a placement: features stay
+// small, while jigsaws and structures such as villages spread out. Both
+// keep the area within maxRCONCapture, so they work without world files.
+const (
+	defaultFeatureRadius   = 4
+	defaultStructureRadius = 8
+	defaultJigsawDepth     = 7
+)
+
+type worldgenPlacementResourceType struct{}
+
+func (t worldgenPlacementResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		MarkdownDescription: "Run **world generation** at a position: a feature such as a tree or ore blob, a jigsaw grown from a template pool, or a whole structure such as a village (wraps `/place feature|jigsaw|structure`, Minecraft 1.19 or later).",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"type": {
+				MarkdownDescription: "What to generate: `feature`, `jigsaw` or `structure`.",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"name": {
+				MarkdownDescription: "The configured feature or structure, e.g. `minecraft:fancy_oak`, `minecraft:ore_diamond` or `minecraft:village_plains`. Required unless `type` is `jigsaw`.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"pool": {
+				MarkdownDescription: "The template pool a jigsaw starts from, e.g. `minecraft:village/plains/town_centers`. Jigsaws only, and required for them.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"target": {
+				MarkdownDescription: "The name of the jigsaw block in the start piece to grow from, e.g. `minecraft:bottom`. Jigsaws only, and required for them.",
+				Optional:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"depth": {
+				MarkdownDescription: fmt.Sprintf("How many jigsaw steps to take from the start piece, from 1 to %d. Jigsaws only. Defaults to `%d`.", minecraft.MaxJigsawDepth, defaultJigsawDepth),
+				Optional:            true,
+				Type:                types.NumberType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"position": fixedPosition("Where to generate."),
+			"radius": {
+				MarkdownDescription: fmt.Sprintf("How far from `position`, in blocks along each axis, to record changes so destroy can undo them. Defaults to `%d` for features and `%d` for jigsaws and structures.", defaultFeatureRadius, defaultStructureRadius),
+				Optional:            true,
+				Type:                types.NumberType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"restore": {
+				MarkdownDescription: "On destroy, put back the blocks generation replaced. When false, the whole of `bounds` is filled with air instead. Defaults to true.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"bounds": {
+				MarkdownDescription: "The box holding every block generation changed, as `x1,y1,z1:x2,y2,z2`. Null when nothing changed.",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"previous_blocks": {
+				MarkdownDescription: "The blocks generation replaced, air included, keyed by `x,y,z`, with their block entity data.",
+				Computed:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"dimension": dimensionAttribute(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "ID of the placement.",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t worldgenPlacementResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+	return worldgenPlacementResource{provider: provider}, diags
+}
+
+type worldgenPlacementResourceData struct {
+	Id        types.String `tfsdk:"id"`
+	Dimension types.String `tfsdk:"dimension"`
+	Type      string       `tfsdk:"type"`
+	Name      types.String `tfsdk:"name"`
+	Pool      types.String `tfsdk:"pool"`
+	Target    types.String `tfsdk:"target"`
+	Depth     *int         `tfsdk:"depth"`
+	Position  struct {
+		X int `tfsdk:"x"`
+		Y int `tfsdk:"y"`
+		Z int `tfsdk:"z"`
+	} `tfsdk:"position"`
+	Radius         *int         `tfsdk:"radius"`
+	Restore        *bool        `tfsdk:"restore"`
+	Bounds         types.String `tfsdk:"bounds"`
+	PreviousBlocks types.Map    `tfsdk:"previous_blocks"`
+}
+
+func (d worldgenPlacementResourceData) placement() minecraft.Placement {
+	p := minecraft.Placement{
+		Kind:     d.Type,
+		Name:     d.Name.Value,
+		Position: minecraft.Pos{X: d.Position.X, Y: d.Position.Y, Z: d.Position.Z},
+	}
+	if d.Type == "jigsaw" {
+		p.Name = d.Pool.Value
+		p.Target = d.Target.Value
+		p.MaxDepth = defaultJigsawDepth
+		if d.Depth != nil {
+			p.MaxDepth = *d.Depth
+		}
+	}
+	return p
+}
+
+// validate checks that only the attributes for the type are set.
+func (d worldgenPlacementResourceData) validate() error {
+	jigsaw := d.Type == "jigsaw"
+	switch {
+	case jigsaw && !d.Name.Null:
+		return fmt.Errorf("jigsaws take a pool and a target, not a name")
+	case !jigsaw && (!d.Pool.Null || !d.Target.Null || d.Depth != nil):
+		return fmt.Errorf("pool, target and depth only apply to jigsaws")
+	}
+	if d.Radius != nil && *d.Radius < 0 {
+		return fmt.Errorf("radius must not be negative (got %d)", *d.Radius)
+	}
+	return d.placement().Validate()
+}
+
+// area returns the box recorded around the position.
+func (d worldgenPlacementResourceData) area() minecraft.Box {
+	radius := defaultStructureRadius
+	if d.Type == "feature" {
+		radius = defaultFeatureRadius
+	}
+	if d.Radius != nil {
+		radius = *d.Radius
+	}
+	return minecraft.Box{
+		Min: minecraft.Pos{X: d.Position.X - radius, Y: d.Position.Y - radius, Z: d.Position.Z - radius},
+		Max: minecraft.Pos{X: d.Position.X + radius, Y: d.Position.Y + radius, Z: d.Position.Z + radius},
+	}
+}
+
+func (d worldgenPlacementResourceData) restore() bool {
+	return d.Restore == nil || *d.Restore
+}
+
+type worldgenPlacementResource struct {
+	provider provider
+}
+
+func (r worldgenPlacementResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data worldgenPlacementResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+	registry, err := r.provider.BlockStates()
+	if err != nil {
+		resp.Diagnostics.AddError("Block State Registry Error", err.Error())
+		return
+	}
+
+	// Generation is random, so the changes are found by reading the area
+	// before and after.
+	area := data.area()
+	before, unknown, err := captureArea(ctx, r.provider, client, registry, data.Dimension, area)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to capture the area, got error: %s", err))
+		return
+	}
+
+	if err := client.Place(ctx, r.provider.ServerVersion(), data.placement()); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to place %s, got error: %s", data.Type, err))
+		return
+	}
+
+	after, _, err := captureArea(ctx, r.provider, client, registry, data.Dimension, area)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to capture the area, got error: %s", err))
+		return
+	}
+
+	data.Bounds = types.String{Null: true}
+	data.PreviousBlocks = types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}}
+	var bounds *minecraft.Box
+	for p, block := range after {
+		previous, ok := before[p]
+		if !ok || previous == block {
+			continue
+		}
+		data.PreviousBlocks.Elems[p.String()] = types.String{Value: previous}
+		if bounds == nil {
+			bounds = &minecraft.Box{Min: p, Max: p}
+		}
+		*bounds = bounds.Include(p)
+	}
+
+	if unknown > 0 {
+		resp.Diagnostics.AddWarning("Unknown Blocks",
+			fmt.Sprintf("%d blocks in the area could not be identified; changes to them will not be undone on destroy.", unknown))
+	}
+	if bounds != nil {
+		data.Bounds = types.String{Value: bounds.String()}
+		if bounds.Min.X == area.Min.X || bounds.Min.Y == area.Min.Y || bounds.Min.Z == area.Min.Z ||
+			bounds.Max.X == area.Max.X || bounds.Max.Y == area.Max.Y || bounds.Max.Z == area.Max.Z {
+			resp.Diagnostics.AddAttributeWarning(tftypes.NewAttributePath().WithAttributeName("radius"), "Placement Reached Edge",
+				fmt.Sprintf("The %s changed blocks at the edge of the recorded area and may reach beyond it; changes outside it will not be undone on destroy.", data.Type))
+		}
+	}
+
+	data.Id = types.String{Value: fmt.Sprintf("placement-%d-%d-%d", data.Position.X, data.Position.Y, data.Position.Z)}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read is a no-op: generation is a one-off, and the result is expected to
+// change in game afterwards.
+func (r worldgenPlacementResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data worldgenPlacementResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only records `restore`; every other argument replaces the
+// placement.
+func (r worldgenPlacementResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data worldgenPlacementResourceData
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete puts back the blocks generation replaced, or clears the bounds.
+// Entities it spawned, such as villagers, are left alone.
+func (r worldgenPlacementResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data worldgenPlacementResourceData
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.Bounds.Null || data.Bounds.Value == "" {
+		return
+	}
+
+	client, err := r.provider.GetClientInDimension(ctx, data.Dimension)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create client, got error: %s", err))
+		return
+	}
+
+	if !data.restore() {
+		bounds, err := minecraft.ParseBox(data.Bounds.Value)
+		if err != nil {
+			resp.Diagnostics.AddError("Validation Error", err.Error())
+			return
+		}
+		bounds.Block = "minecraft:air"
+		for _, box := range minecraft.SplitBox(bounds) {
+			if err := client.FillBox(ctx, box); err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to clear placement, got error: %s", err))
+				return
+			}
+		}
+		return
+	}
+
+	var blocks map[string]string
+	resp.Diagnostics.Append(data.PreviousBlocks.ElementsAs(ctx, &blocks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	previous, err := parseBlockMap(blocks)
+	if err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+	if _, err := client.SetBlocks(ctx, previous); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to restore the area, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan checks the settings, the size of the recorded area and the
+// server version before anything is sent to the server.
+func (r worldgenPlacementResource) ModifyPlan(ctx context.Context, req tfsdk.ModifyResourcePlanRequest, resp *tfsdk.ModifyResourcePlanResponse) {
+	if req.Plan.Raw.IsNull() || !req.Config.Raw.IsFullyKnown() {
+		return
+	}
+
+	var data worldgenPlacementResourceData
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := data.validate(); err != nil {
+		resp.Diagnostics.AddError("Validation Error", err.Error())
+		return
+	}
+	if err := checkCaptureVolume(r.provider, data.area()); err != nil {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("radius"), "Validation Error", err.Error())
+		return
+	}
+	if version := r.provider.ServerVersion(); !minecraft.PlaceSupported(version) {
+		resp.Diagnostics.AddAttributeError(tftypes.NewAttributePath().WithAttributeName("type"), "Unsupported Placement",
+			fmt.Sprintf("Placing world generation needs Minecraft 1.19 or later; the server is %s.", version))
+	}
+}
+
+func (r worldgenPlacementResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+}